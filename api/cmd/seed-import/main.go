@@ -0,0 +1,105 @@
+// Command seed-import loads a per-country seed file (JSON) and writes its
+// country/states/holidays into Postgres, upserting so it's safe to re-run
+// as a country's gazette data is revised.
+//
+// Usage:
+//
+//	seed-import -file seeds/sg.json
+//	seed-import -file seeds/sg.json -year 2026
+//
+// The seed file's `holidays` are imported verbatim. If -year is given and
+// the country code has a registered providers.CountryProvider, that
+// provider's ComputeDerived is also called and its results upserted - this
+// is how derived (rule-expanded) holidays for years not covered by
+// explicitly gazetted rows get persisted. Without -year, or for a country
+// with no registered provider, this tool only imports what's in the file.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/providers"
+)
+
+// seedFile is the on-disk shape of a per-country seed import.
+type seedFile struct {
+	Country  models.Country   `json:"country"`
+	States   []models.State   `json:"states"`
+	Holidays []models.Holiday `json:"holidays"`
+}
+
+func main() {
+	filePath := flag.String("file", "", "path to the country seed JSON file")
+	year := flag.Int("year", 0, "also recompute and upsert derived holidays for this year via the country's registered CountryProvider.ComputeDerived")
+	flag.Parse()
+
+	if *filePath == "" {
+		log.Fatal("seed-import: -file is required")
+	}
+
+	raw, err := os.ReadFile(*filePath)
+	if err != nil {
+		log.Fatalf("seed-import: failed to read %s: %v", *filePath, err)
+	}
+
+	var seed seedFile
+	if err := json.Unmarshal(raw, &seed); err != nil {
+		log.Fatalf("seed-import: failed to parse %s: %v", *filePath, err)
+	}
+
+	if seed.Country.Code == "" {
+		log.Fatal("seed-import: seed file is missing country.code")
+	}
+
+	if err := db.Connect(); err != nil {
+		log.Fatalf("seed-import: failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	if err := db.UpsertCountry(ctx, seed.Country); err != nil {
+		log.Fatalf("seed-import: failed to upsert country %s: %v", seed.Country.Code, err)
+	}
+
+	for _, s := range seed.States {
+		if err := db.UpsertState(ctx, seed.Country.Code, s); err != nil {
+			log.Fatalf("seed-import: failed to upsert state %s: %v", s.Code, err)
+		}
+	}
+
+	for _, h := range seed.Holidays {
+		if err := db.UpsertHoliday(ctx, seed.Country.Code, h); err != nil {
+			log.Fatalf("seed-import: failed to upsert holiday %s: %v", h.ID, err)
+		}
+	}
+
+	log.Printf("seed-import: imported %s: %d states, %d holidays", seed.Country.Code, len(seed.States), len(seed.Holidays))
+
+	if *year > 0 {
+		provider, ok := providers.Get(seed.Country.Code)
+		if !ok {
+			log.Printf("seed-import: no registered CountryProvider for %s, skipping -year %d derivation", seed.Country.Code, *year)
+			return
+		}
+
+		derived, err := provider.ComputeDerived(ctx, *year)
+		if err != nil {
+			log.Fatalf("seed-import: failed to compute derived holidays for %s %d: %v", seed.Country.Code, *year, err)
+		}
+
+		for _, h := range derived {
+			if err := db.UpsertHoliday(ctx, seed.Country.Code, h); err != nil {
+				log.Fatalf("seed-import: failed to upsert derived holiday %s: %v", h.ID, err)
+			}
+		}
+
+		log.Printf("seed-import: derived %d holidays for %s %d", len(derived), seed.Country.Code, *year)
+	}
+}
@@ -0,0 +1,255 @@
+// Package cache holds an in-memory snapshot of the (small, nearly-static)
+// holidays/states dataset so hot read paths don't have to round-trip to
+// Postgres on every request. The snapshot is refreshed on an interval, on
+// SIGHUP, or on demand via POST /admin/reload.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+)
+
+// snapshot is an immutable point-in-time copy of the dataset. Reloads build
+// a new snapshot and swap the pointer rather than mutating in place, so
+// readers never observe a half-updated cache.
+type snapshot struct {
+	holidays    []models.Holiday
+	states      []models.State
+	stateByCode map[string]models.State
+	dataVersion string
+	loadedAt    time.Time
+}
+
+var (
+	mu      sync.RWMutex
+	current *snapshot
+)
+
+// Load fetches the full holidays/states dataset from the database and
+// swaps it in as the current snapshot. Call once at startup, then again on
+// whatever cadence StartBackgroundRefresh/WatchSIGHUP/an admin reload picks.
+func Load(ctx context.Context) error {
+	holidays, err := db.GetHolidays(ctx, 0, "", 0, true)
+	if err != nil {
+		return fmt.Errorf("cache: failed to load holidays: %w", err)
+	}
+
+	states, err := db.GetStates(ctx)
+	if err != nil {
+		return fmt.Errorf("cache: failed to load states: %w", err)
+	}
+
+	stateByCode := make(map[string]models.State, len(states))
+	for _, s := range states {
+		stateByCode[s.Code] = s
+	}
+
+	snap := &snapshot{
+		holidays:    holidays,
+		states:      states,
+		stateByCode: stateByCode,
+		dataVersion: computeDataVersion(holidays),
+		loadedAt:    time.Now(),
+	}
+
+	mu.Lock()
+	current = snap
+	mu.Unlock()
+
+	return nil
+}
+
+// computeDataVersion hashes the IDs and dates of the loaded holidays into a
+// short fingerprint so handlers can derive a weak ETag without re-hashing
+// the (much larger) response body on every request.
+func computeDataVersion(holidays []models.Holiday) string {
+	h := sha256.New()
+	for _, hol := range holidays {
+		fmt.Fprintf(h, "%s|%s;", hol.ID, hol.Date.Time.Format("2006-01-02"))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func get() *snapshot {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Loaded reports whether a snapshot has been loaded yet.
+func Loaded() bool {
+	return get() != nil
+}
+
+// DataVersion returns the fingerprint of the currently loaded snapshot, or
+// an empty string if nothing has been loaded yet.
+func DataVersion() string {
+	snap := get()
+	if snap == nil {
+		return ""
+	}
+	return snap.dataVersion
+}
+
+func containsState(states []string, code string) bool {
+	for _, s := range states {
+		if s == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Holidays mirrors db.GetHolidays' filter semantics but reads from the
+// in-memory snapshot. The bool return is false when no snapshot has been
+// loaded yet, signaling callers to fall back to the database - it's also
+// false whenever a specific year's filtered result is empty, since
+// db.GetHolidays falls back to rule-expansion (internal/rules) when that
+// year has no gazetted rows and the snapshot has no way to do the same.
+func Holidays(year int, stateCode string, month int, includeReplacements bool) ([]models.Holiday, bool) {
+	snap := get()
+	if snap == nil {
+		return nil, false
+	}
+
+	var out []models.Holiday
+	for _, h := range snap.holidays {
+		if year > 0 && h.Date.Time.Year() != year {
+			continue
+		}
+		if month > 0 && int(h.Date.Time.Month()) != month {
+			continue
+		}
+		if !includeReplacements && h.IsReplacementHoliday {
+			continue
+		}
+		if stateCode != "" && !containsState(h.States, stateCode) {
+			continue
+		}
+		out = append(out, h)
+	}
+
+	if year > 0 && len(out) == 0 {
+		return nil, false
+	}
+	return out, true
+}
+
+// HolidaysForDate mirrors db.GetHolidaysForDate.
+func HolidaysForDate(date time.Time, stateCode string) ([]models.Holiday, bool) {
+	snap := get()
+	if snap == nil {
+		return nil, false
+	}
+
+	var out []models.Holiday
+	for _, h := range snap.holidays {
+		if !h.Date.Time.Equal(date) {
+			continue
+		}
+		if stateCode != "" && !containsState(h.States, stateCode) {
+			continue
+		}
+		out = append(out, h)
+	}
+	return out, true
+}
+
+// HolidaysInRangeMap mirrors db.GetHolidaysInRangeMap, including its
+// formatted-date-string keying.
+func HolidaysInRangeMap(start, end time.Time, stateCode string) (map[string]bool, bool) {
+	snap := get()
+	if snap == nil {
+		return nil, false
+	}
+
+	m := make(map[string]bool)
+	for _, h := range snap.holidays {
+		d := h.Date.Time
+		if d.Before(start) || d.After(end) {
+			continue
+		}
+		if stateCode != "" && !containsState(h.States, stateCode) {
+			continue
+		}
+		m[d.Format("2006-01-02")] = true
+	}
+	return m, true
+}
+
+// States mirrors db.GetStates.
+func States() ([]models.State, bool) {
+	snap := get()
+	if snap == nil {
+		return nil, false
+	}
+	return snap.states, true
+}
+
+// StateByCode mirrors db.GetState.
+func StateByCode(code string) (*models.State, bool) {
+	snap := get()
+	if snap == nil {
+		return nil, false
+	}
+	s, found := snap.stateByCode[code]
+	if !found {
+		return nil, true
+	}
+	return &s, true
+}
+
+// StartBackgroundRefresh reloads the snapshot on a fixed interval until ctx
+// is canceled. A failed reload logs and leaves the previous snapshot in
+// place rather than tearing the cache down.
+func StartBackgroundRefresh(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := Load(ctx); err != nil {
+					log.Printf("cache: background refresh failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// WatchSIGHUP reloads the snapshot whenever the process receives SIGHUP,
+// the conventional "re-read your config/data" signal for long-running
+// Unix services.
+func WatchSIGHUP(ctx context.Context) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				signal.Stop(sig)
+				return
+			case <-sig:
+				log.Println("cache: SIGHUP received, reloading")
+				if err := Load(ctx); err != nil {
+					log.Printf("cache: reload on SIGHUP failed: %v", err)
+				}
+			}
+		}
+	}()
+}
@@ -3,10 +3,14 @@ package db
 import (
 	"context"
 	"fmt"
+	"log"
+	"sort"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/metrics"
 	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/rules"
 )
 
 // GetStates retrieves all states
@@ -43,8 +47,175 @@ func GetState(ctx context.Context, code string) (*models.State, error) {
 	return &s, nil
 }
 
+// GetCountries retrieves all countries registered with the API.
+func GetCountries(ctx context.Context) ([]models.Country, error) {
+	query := `SELECT code, name FROM countries ORDER BY name`
+	rows, err := Pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var countries []models.Country
+	for rows.Next() {
+		var c models.Country
+		if err := rows.Scan(&c.Code, &c.Name); err != nil {
+			return nil, err
+		}
+		countries = append(countries, c)
+	}
+	return countries, nil
+}
+
+// GetCountry retrieves a single country by its ISO 3166-1 alpha-2 code.
+func GetCountry(ctx context.Context, code string) (*models.Country, error) {
+	query := `SELECT code, name FROM countries WHERE code = $1`
+	var c models.Country
+	err := Pool.QueryRow(ctx, query, code).Scan(&c.Code, &c.Name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// UpsertCountry inserts or updates a country row. Used by the seed-import
+// tool when onboarding a new CountryProvider.
+func UpsertCountry(ctx context.Context, c models.Country) error {
+	query := `
+		INSERT INTO countries (code, name)
+		VALUES ($1, $2)
+		ON CONFLICT (code) DO UPDATE SET name = EXCLUDED.name
+	`
+	_, err := Pool.Exec(ctx, query, c.Code, c.Name)
+	return err
+}
+
+// UpsertState inserts or updates a state row for the given country.
+func UpsertState(ctx context.Context, countryCode string, s models.State) error {
+	query := `
+		INSERT INTO states (code, name, name_ms, weekend_days, weekend_pattern, saturday_replacement_rule, country_code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (code) DO UPDATE SET
+			name = EXCLUDED.name,
+			name_ms = EXCLUDED.name_ms,
+			weekend_days = EXCLUDED.weekend_days,
+			weekend_pattern = EXCLUDED.weekend_pattern,
+			saturday_replacement_rule = EXCLUDED.saturday_replacement_rule,
+			country_code = EXCLUDED.country_code
+	`
+	_, err := Pool.Exec(ctx, query, s.Code, s.Name, s.NameMs, s.WeekendDays, s.WeekendPattern, s.SaturdayReplacementRule, countryCode)
+	return err
+}
+
+// UpsertHoliday inserts or updates a holiday row and its holiday_states
+// associations for the given country.
+func UpsertHoliday(ctx context.Context, countryCode string, h models.Holiday) error {
+	query := `
+		INSERT INTO holidays (id, name, name_en, date, day_of_week, type, is_replacement_holiday,
+			original_date, original_holiday_id, replaced_by, replacement_reason,
+			description, religion, gazette_reference, declared_date, country_code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			name_en = EXCLUDED.name_en,
+			date = EXCLUDED.date,
+			day_of_week = EXCLUDED.day_of_week,
+			type = EXCLUDED.type,
+			is_replacement_holiday = EXCLUDED.is_replacement_holiday,
+			original_date = EXCLUDED.original_date,
+			original_holiday_id = EXCLUDED.original_holiday_id,
+			replaced_by = EXCLUDED.replaced_by,
+			replacement_reason = EXCLUDED.replacement_reason,
+			description = EXCLUDED.description,
+			religion = EXCLUDED.religion,
+			gazette_reference = EXCLUDED.gazette_reference,
+			declared_date = EXCLUDED.declared_date,
+			country_code = EXCLUDED.country_code
+	`
+	_, err := Pool.Exec(ctx, query,
+		h.ID, h.Name, h.NameEn, h.Date, h.DayOfWeek, h.Type, h.IsReplacementHoliday,
+		h.OriginalDate, h.OriginalHolidayID, h.ReplacedBy, h.ReplacementReason,
+		h.Description, h.Religion, h.GazetteReference, h.DeclaredDate, countryCode,
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := Pool.Exec(ctx, `DELETE FROM holiday_states WHERE holiday_id = $1`, h.ID); err != nil {
+		return err
+	}
+	for _, stateCode := range h.States {
+		if _, err := Pool.Exec(ctx, `INSERT INTO holiday_states (holiday_id, state_code) VALUES ($1, $2)`, h.ID, stateCode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetHolidayRules retrieves all registered recurring-holiday rules.
+func GetHolidayRules(ctx context.Context) ([]models.HolidayRule, error) {
+	query := `
+		SELECT hr.id, hr.name, hr.name_en, hr.rrule, hr.observance_shift, hr.type,
+		       hr.description, hr.religion, hr.gazette_reference,
+		       COALESCE(array_agg(hrs.state_code) FILTER (WHERE hrs.state_code IS NOT NULL), '{}')
+		FROM holiday_rules hr
+		LEFT JOIN holiday_rule_states hrs ON hr.id = hrs.rule_id
+		GROUP BY hr.id
+		ORDER BY hr.id
+	`
+	rows, err := Pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []models.HolidayRule
+	for rows.Next() {
+		var rule models.HolidayRule
+		if err := rows.Scan(
+			&rule.ID, &rule.Name, &rule.NameEn, &rule.RRule, &rule.ObservanceShift, &rule.Type,
+			&rule.Description, &rule.Religion, &rule.GazetteReference, &rule.States,
+		); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// CreateHolidayRule inserts a new recurring-holiday rule and its applicable
+// states.
+func CreateHolidayRule(ctx context.Context, rule models.HolidayRule) error {
+	query := `
+		INSERT INTO holiday_rules (id, name, name_en, rrule, observance_shift, type, description, religion, gazette_reference)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	if _, err := Pool.Exec(ctx, query,
+		rule.ID, rule.Name, rule.NameEn, rule.RRule, rule.ObservanceShift, rule.Type,
+		rule.Description, rule.Religion, rule.GazetteReference,
+	); err != nil {
+		return err
+	}
+
+	for _, stateCode := range rule.States {
+		if _, err := Pool.Exec(ctx, `INSERT INTO holiday_rule_states (rule_id, state_code) VALUES ($1, $2)`, rule.ID, stateCode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetHolidays retrieves holidays with optional filters
 func GetHolidays(ctx context.Context, year int, stateCode string, month int, includeReplacements bool) ([]models.Holiday, error) {
+	defer func(start time.Time) {
+		metrics.DBQueryDuration.WithLabelValues("GetHolidays").Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	// Base query
 	sql := `
 		SELECT h.id, h.name, h.name_en, h.date, h.day_of_week, h.type, h.is_replacement_holiday, 
@@ -137,9 +308,79 @@ func GetHolidays(ctx context.Context, year int, stateCode string, month int, inc
 		h.States = states
 		holidays = append(holidays, h)
 	}
+
+	// Fall back to rule-expansion only when the year has no gazetted rows
+	// at all - if even one row was seeded, assume a curator already
+	// reviewed that year and don't risk a rule-derived duplicate sitting
+	// next to it.
+	if year > 0 && len(holidays) == 0 {
+		var seeded bool
+		if err := Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM holidays WHERE EXTRACT(YEAR FROM date) = $1)`, year).Scan(&seeded); err != nil {
+			return nil, err
+		}
+		if !seeded {
+			expanded, err := ExpandRulesForYear(ctx, year, month, stateCode)
+			if err != nil {
+				return nil, err
+			}
+			holidays = append(holidays, expanded...)
+		}
+	}
+
 	return holidays, nil
 }
 
+// ExpandRulesForYear materializes every registered HolidayRule into
+// occurrences for year, applying the same month/state filters GetHolidays
+// would otherwise have pushed down into SQL. It's also the engine behind
+// providers.CountryProvider.ComputeDerived - exported so a provider can
+// recompute the same occurrences seed-import would persist, without
+// duplicating the rule-expansion logic.
+func ExpandRulesForYear(ctx context.Context, year, month int, stateCode string) ([]models.Holiday, error) {
+	ruleSet, err := GetHolidayRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []models.Holiday
+	for _, rule := range ruleSet {
+		occurrences, err := rules.Expand(rule, year)
+		if err != nil {
+			// A single malformed rule (which CreateHolidayRule should have
+			// rejected, but rows can still end up bad via direct DB access)
+			// shouldn't take down every other rule's expansion for every
+			// other year - skip it and keep going.
+			log.Printf("db: skipping holiday rule %q for year %d: %v", rule.ID, year, err)
+			continue
+		}
+
+		for _, h := range occurrences {
+			if month > 0 && int(h.Date.Time.Month()) != month {
+				continue
+			}
+			if stateCode != "" && !containsState(h.States, stateCode) {
+				continue
+			}
+			holidays = append(holidays, h)
+		}
+	}
+
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Time.Before(holidays[j].Date.Time)
+	})
+	return holidays, nil
+}
+
+// containsState reports whether states contains stateCode.
+func containsState(states []string, stateCode string) bool {
+	for _, s := range states {
+		if s == stateCode {
+			return true
+		}
+	}
+	return false
+}
+
 // GetHolidayByID retrieves a single holiday
 func GetHolidayByID(ctx context.Context, id string) (*models.Holiday, error) {
 	sql := `
@@ -278,6 +519,26 @@ func GetHolidaysInRange(ctx context.Context, start, end time.Time, stateCode str
 	return holidays, nil
 }
 
+// GetHolidaysInRangeMap is a bulk-fetch variant of GetHolidaysInRange that
+// returns holiday dates as a map keyed by formatted date (matching
+// CalculateWorkingDays' convention) for O(1) lookup, so callers walking a
+// range day-by-day (e.g. working-day navigation) don't need to scan the
+// slice on every iteration. Keying by the formatted string rather than the
+// time.Time itself avoids equality mismatches between Postgres-scanned and
+// time.Parse-derived values (differing monotonic readings/locations).
+func GetHolidaysInRangeMap(ctx context.Context, start, end time.Time, stateCode string) (map[string]bool, error) {
+	holidays, err := GetHolidaysInRange(ctx, start, end, stateCode)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]bool, len(holidays))
+	for _, h := range holidays {
+		m[h.Date.Time.Format("2006-01-02")] = true
+	}
+	return m, nil
+}
+
 // GetUpcomingHolidays retrieves upcoming holidays
 func GetUpcomingHolidays(ctx context.Context, stateCode string, limit int) ([]models.Holiday, error) {
 	sql := `
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/cache"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/utils"
+)
+
+// AdminReloadCache handles POST /admin/reload, forcing an immediate cache
+// refresh without waiting for the background interval or a SIGHUP. Gated by
+// isAuthorizedAdmin like CreateHolidayRule - this forces a full holidays+
+// states DB reload on demand, and CORS elsewhere in the router is wide open,
+// so it can't be left for any caller to trigger.
+func AdminReloadCache(w http.ResponseWriter, r *http.Request) {
+	if !isAuthorizedAdmin(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="admin"`)
+		utils.RespondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Missing or invalid admin credentials")
+		return
+	}
+
+	if err := cache.Load(r.Context()); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to reload cache")
+		return
+	}
+
+	data := struct {
+		DataVersion string `json:"data_version"`
+	}{
+		DataVersion: cache.DataVersion(),
+	}
+
+	utils.RespondJSON(w, http.StatusOK, data)
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/cache"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/utils"
+)
+
+var errInvalidWorkingHours = errors.New("invalid working_hours")
+
+// availabilityRequest is the POST /availability request body.
+type availabilityRequest struct {
+	Start        string   `json:"start"`
+	End          string   `json:"end"`
+	State        string   `json:"state"`
+	WorkingHours string   `json:"working_hours"` // e.g. "09:00-18:00"; defaults to the full day
+	ExcludeDates []string `json:"exclude_dates"` // YYYY-MM-DD, treated as additionally busy
+}
+
+// freeBusyInterval mirrors iCalendar FREEBUSY semantics: a contiguous UTC
+// time range tagged BUSY-UNAVAILABLE (holiday), BUSY (weekend), or FREE
+// (working time).
+type freeBusyInterval struct {
+	Start  string `json:"start"`
+	End    string `json:"end"`
+	FBType string `json:"fbtype"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// GetAvailability handles POST /availability, returning merged busy/free
+// intervals across the requested range so scheduling tools can consume it
+// directly, analogous to a CalDAV free/busy lookup.
+func GetAvailability(w http.ResponseWriter, r *http.Request) {
+	var req availabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_BODY", "Invalid JSON body")
+		return
+	}
+
+	if req.Start == "" || req.End == "" || req.State == "" {
+		utils.RespondError(w, http.StatusBadRequest, "MISSING_PARAMETER", "start, end, and state are required")
+		return
+	}
+
+	start, err := time.Parse("2006-01-02", req.Start)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "Invalid start date")
+		return
+	}
+
+	end, err := time.Parse("2006-01-02", req.End)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "Invalid end date")
+		return
+	}
+
+	if end.Before(start) {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "end must be after start")
+		return
+	}
+
+	workStart, workEnd, err := parseWorkingHours(req.WorkingHours)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "working_hours must be HH:MM-HH:MM")
+		return
+	}
+
+	state, err := db.GetState(r.Context(), req.State)
+	if err != nil || state == nil {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "State not found")
+		return
+	}
+
+	weekendSet := make(map[string]bool)
+	for _, d := range state.WeekendDays {
+		weekendSet[d] = true
+	}
+
+	excluded := make(map[string]bool, len(req.ExcludeDates))
+	for _, d := range req.ExcludeDates {
+		excluded[d] = true
+	}
+
+	holidayMap, cached := cache.HolidaysInRangeMap(start, end, req.State)
+	if !cached {
+		holidayMap, err = db.GetHolidaysInRangeMap(r.Context(), start, end, req.State)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
+			return
+		}
+	}
+
+	var busy, free []freeBusyInterval
+	var openBusy, openFree *freeBusyInterval
+
+	for curr := start; !curr.After(end); curr = curr.AddDate(0, 0, 1) {
+		dateStr := curr.Format("2006-01-02")
+		dayStart := curr.Add(workStart)
+		dayEnd := curr.Add(workEnd)
+
+		isHoliday := holidayMap[dateStr]
+		isExcluded := excluded[dateStr]
+		isWeekend := weekendSet[curr.Format("Monday")]
+
+		switch {
+		case isHoliday || isExcluded:
+			reason := "holiday"
+			if !isHoliday {
+				reason = "excluded"
+			}
+			openFree = flushInterval(&free, openFree)
+			openBusy = extendOrAppend(&busy, openBusy, dayStart, dayEnd, "BUSY-UNAVAILABLE", reason)
+		case isWeekend:
+			openFree = flushInterval(&free, openFree)
+			openBusy = extendOrAppend(&busy, openBusy, dayStart, dayEnd, "BUSY", "weekend")
+		default:
+			openBusy = flushInterval(&busy, openBusy)
+			openFree = extendOrAppend(&free, openFree, dayStart, dayEnd, "FREE", "")
+		}
+	}
+	flushInterval(&busy, openBusy)
+	flushInterval(&free, openFree)
+
+	if busy == nil {
+		busy = []freeBusyInterval{}
+	}
+	if free == nil {
+		free = []freeBusyInterval{}
+	}
+
+	data := struct {
+		Start string             `json:"start"`
+		End   string             `json:"end"`
+		State string             `json:"state"`
+		Busy  []freeBusyInterval `json:"busy"`
+		Free  []freeBusyInterval `json:"free"`
+	}{
+		Start: req.Start,
+		End:   req.End,
+		State: req.State,
+		Busy:  busy,
+		Free:  free,
+	}
+
+	utils.RespondJSON(w, http.StatusOK, data)
+}
+
+// parseWorkingHours parses an "HH:MM-HH:MM" string into offsets from
+// midnight. An empty string means the full day (00:00-24:00).
+func parseWorkingHours(s string) (start, end time.Duration, err error) {
+	if s == "" {
+		return 0, 24 * time.Hour, nil
+	}
+
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, errInvalidWorkingHours
+	}
+
+	startT, err := time.Parse("15:04", parts[0])
+	if err != nil {
+		return 0, 0, errInvalidWorkingHours
+	}
+	endT, err := time.Parse("15:04", parts[1])
+	if err != nil {
+		return 0, 0, errInvalidWorkingHours
+	}
+
+	start = time.Duration(startT.Hour())*time.Hour + time.Duration(startT.Minute())*time.Minute
+	end = time.Duration(endT.Hour())*time.Hour + time.Duration(endT.Minute())*time.Minute
+	if end <= start {
+		return 0, 0, errInvalidWorkingHours
+	}
+
+	return start, end, nil
+}
+
+// extendOrAppend merges a new [from, to) span into the currently-open
+// interval if it's contiguous with it, otherwise flushes the open interval
+// and starts a new one.
+func extendOrAppend(out *[]freeBusyInterval, open *freeBusyInterval, from, to time.Time, fbtype, reason string) *freeBusyInterval {
+	if open != nil && open.FBType == fbtype && open.Reason == reason {
+		openEnd, _ := time.Parse(time.RFC3339, open.End)
+		if openEnd.Equal(from) {
+			open.End = to.UTC().Format(time.RFC3339)
+			return open
+		}
+	}
+
+	flushInterval(out, open)
+	return &freeBusyInterval{
+		Start:  from.UTC().Format(time.RFC3339),
+		End:    to.UTC().Format(time.RFC3339),
+		FBType: fbtype,
+		Reason: reason,
+	}
+}
+
+// flushInterval appends the open interval (if any) to out and returns nil,
+// so callers can reassign their "open interval" pointer in one line.
+func flushInterval(out *[]freeBusyInterval, open *freeBusyInterval) *freeBusyInterval {
+	if open != nil {
+		*out = append(*out, *open)
+	}
+	return nil
+}
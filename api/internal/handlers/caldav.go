@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/utils"
+)
+
+// calDAVBackend implements caldav.Backend for a single collection - either
+// "federal" (every gazetted holiday, unfiltered by state, the same meaning
+// `stateCode == ""` already has in db.GetHolidays) or one specific state.
+// One Handler/Backend pair is constructed per request rather than kept
+// around, since the underlying query is cheap and this avoids needing to
+// invalidate a long-lived Backend when the cache reloads.
+type calDAVBackend struct {
+	calendarID string // path segment under /caldav/, e.g. "federal" or "JHR"
+	stateCode  string // "" for the federal collection
+	name       string
+}
+
+// listWindow bounds how far back/forward ListCalendarObjects looks when a
+// client asks for the whole collection rather than a time-range REPORT.
+// Holidays are unbounded going forward once rule-expansion (internal/rules)
+// is involved, so "the whole collection" has to mean something finite.
+const (
+	listWindowPast   = -1 // years
+	listWindowFuture = 5  // years
+)
+
+func (b *calDAVBackend) CalendarHomeSetPath(ctx context.Context) (string, error) {
+	return "/caldav/" + b.calendarID + "/", nil
+}
+
+// CurrentUserPrincipal implements webdav.UserPrincipalBackend. This is a
+// single-collection-per-country-or-state, no-accounts server, so every
+// caller is pointed at the same principal.
+func (b *calDAVBackend) CurrentUserPrincipal(ctx context.Context) (string, error) {
+	return "/caldav/" + b.calendarID + "/", nil
+}
+
+func (b *calDAVBackend) calendar() *caldav.Calendar {
+	path, _ := b.CalendarHomeSetPath(context.Background())
+	return &caldav.Calendar{
+		Path:                  path,
+		Name:                  b.name,
+		SupportedComponentSet: []string{ical.CompEvent},
+	}
+}
+
+// ListCalendars implements caldav.Backend. There's exactly one calendar per
+// backend instance (see resolveCalDAVBackend), so this just wraps it.
+func (b *calDAVBackend) ListCalendars(ctx context.Context) ([]caldav.Calendar, error) {
+	return []caldav.Calendar{*b.calendar()}, nil
+}
+
+// GetCalendar implements caldav.Backend.
+func (b *calDAVBackend) GetCalendar(ctx context.Context, path string) (*caldav.Calendar, error) {
+	return b.calendar(), nil
+}
+
+// CreateCalendar is unimplemented - see the read-only note on
+// PutCalendarObject/DeleteCalendarObject below.
+func (b *calDAVBackend) CreateCalendar(ctx context.Context, calendar *caldav.Calendar) error {
+	return fmt.Errorf("caldav: %s is read-only", b.calendarID)
+}
+
+func (b *calDAVBackend) ListCalendarObjects(ctx context.Context, path string, req *caldav.CalendarCompRequest) ([]caldav.CalendarObject, error) {
+	now := time.Now()
+	start := now.AddDate(listWindowPast, 0, 0)
+	end := now.AddDate(listWindowFuture, 0, 0)
+
+	holidays, err := db.GetHolidaysInRange(ctx, start, end, b.stateCode)
+	if err != nil {
+		return nil, err
+	}
+	return b.toCalendarObjects(holidays)
+}
+
+func (b *calDAVBackend) QueryCalendarObjects(ctx context.Context, path string, query *caldav.CalendarQuery) ([]caldav.CalendarObject, error) {
+	start, end, ok := timeRangeFromQuery(query)
+	if !ok {
+		now := time.Now()
+		start, end = now.AddDate(listWindowPast, 0, 0), now.AddDate(listWindowFuture, 0, 0)
+	}
+
+	holidays, err := db.GetHolidaysInRange(ctx, start, end, b.stateCode)
+	if err != nil {
+		return nil, err
+	}
+	return b.toCalendarObjects(holidays)
+}
+
+// timeRangeFromQuery pulls the first time-range filter out of a
+// `REPORT calendar-query`'s nested comp-filter tree. CalDAV lets the
+// time-range live on any filter depth (usually VCALENDAR/VEVENT), so this
+// walks the tree rather than assuming a fixed depth.
+func timeRangeFromQuery(query *caldav.CalendarQuery) (start, end time.Time, ok bool) {
+	if query == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return timeRangeFromCompFilter(query.CompFilter)
+}
+
+func timeRangeFromCompFilter(f caldav.CompFilter) (start, end time.Time, ok bool) {
+	if !f.Start.IsZero() || !f.End.IsZero() {
+		return f.Start, f.End, true
+	}
+	for _, child := range f.Comps {
+		if start, end, ok = timeRangeFromCompFilter(child); ok {
+			return start, end, true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+func (b *calDAVBackend) GetCalendarObject(ctx context.Context, path string, req *caldav.CalendarCompRequest) (*caldav.CalendarObject, error) {
+	id := strings.TrimSuffix(pathBase(path), ".ics")
+
+	h, err := db.GetHolidayByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if h == nil || (b.stateCode != "" && !containsStateCode(h.States, b.stateCode)) {
+		return nil, fmt.Errorf("caldav: %s: not found", path)
+	}
+
+	return b.toCalendarObject(*h)
+}
+
+// pathBase is a tiny path.Base that doesn't special-case "." for an empty
+// path, since resource paths here are always CalDAV-supplied.
+func pathBase(p string) string {
+	p = strings.TrimSuffix(p, "/")
+	if i := strings.LastIndex(p, "/"); i >= 0 {
+		return p[i+1:]
+	}
+	return p
+}
+
+func containsStateCode(states []string, stateCode string) bool {
+	for _, s := range states {
+		if s == stateCode {
+			return true
+		}
+	}
+	return false
+}
+
+// PutCalendarObject and DeleteCalendarObject are unimplemented - this is
+// deliberately a read-only CalDAV surface so calendar clients can subscribe
+// and get range queries, not a two-way sync target.
+func (b *calDAVBackend) PutCalendarObject(ctx context.Context, path string, calendar *ical.Calendar, opts *caldav.PutCalendarObjectOptions) (*caldav.CalendarObject, error) {
+	return nil, fmt.Errorf("caldav: %s is read-only", b.calendarID)
+}
+
+func (b *calDAVBackend) DeleteCalendarObject(ctx context.Context, path string) error {
+	return fmt.Errorf("caldav: %s is read-only", b.calendarID)
+}
+
+// toCalendarObject renders a single holiday through the same buildVEvent/
+// buildICSCalendar text serialization the .ics endpoints use (see ics.go),
+// then decodes it back into the typed *ical.Calendar the caldav package
+// works with - so both surfaces agree on how a holiday maps onto a VEVENT.
+func (b *calDAVBackend) toCalendarObject(h models.Holiday) (*caldav.CalendarObject, error) {
+	icsText := buildICSCalendar([]models.Holiday{h}, b.name)
+
+	decoded, err := ical.NewDecoder(strings.NewReader(icsText)).Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	lastModified := h.Date.Time
+	if h.DeclaredDate != nil {
+		lastModified = h.DeclaredDate.Time
+	}
+
+	return &caldav.CalendarObject{
+		Path:    fmt.Sprintf("/caldav/%s/%s.ics", b.calendarID, h.ID),
+		ModTime: lastModified,
+		ETag:    fmt.Sprintf("%q", h.ID),
+		Data:    decoded,
+	}, nil
+}
+
+func (b *calDAVBackend) toCalendarObjects(holidays []models.Holiday) ([]caldav.CalendarObject, error) {
+	objs := make([]caldav.CalendarObject, 0, len(holidays))
+	for _, h := range holidays {
+		obj, err := b.toCalendarObject(h)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, *obj)
+	}
+	return objs, nil
+}
+
+// resolveCalDAVBackend maps a /caldav/{calendar_id} path segment onto a
+// backend - "federal" for the unfiltered, all-states collection, or a
+// specific state's code.
+func resolveCalDAVBackend(ctx context.Context, calendarID string) (*calDAVBackend, error) {
+	if calendarID == "federal" {
+		return &calDAVBackend{calendarID: "federal", name: "Malaysia Public Holidays"}, nil
+	}
+
+	state, err := db.GetState(ctx, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, nil
+	}
+
+	return &calDAVBackend{
+		calendarID: state.Code,
+		stateCode:  state.Code,
+		name:       fmt.Sprintf("Malaysia Public Holidays - %s", state.Code),
+	}, nil
+}
+
+// CalDAV handles every method (PROPFIND, REPORT, GET, OPTIONS, ...) under
+// /caldav/{calendar_id}/*, dispatching into the go-webdav caldav.Handler for
+// that calendar.
+func CalDAV(w http.ResponseWriter, r *http.Request) {
+	calendarID := chi.URLParam(r, "calendar_id")
+
+	backend, err := resolveCalDAVBackend(r.Context(), calendarID)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to resolve calendar")
+		return
+	}
+	if backend == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	handler := &caldav.Handler{
+		Backend: backend,
+		Prefix:  "/caldav/" + calendarID,
+	}
+	handler.ServeHTTP(w, r)
+}
+
+// CalDAVAuth gates /caldav/* behind an API key - several calendar clients
+// (notably macOS/iOS Calendar) won't treat an unauthenticated CalDAV
+// collection as subscribable. Auth is skipped when CALDAV_API_KEY isn't
+// set, so local/dev setups need no extra configuration.
+func CalDAVAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := os.Getenv("CALDAV_API_KEY")
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if _, pass, ok := r.BasicAuth(); ok && pass == key {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.Header.Get("X-Api-Key") == key {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("WWW-Authenticate", `Basic realm="caldav"`)
+		utils.RespondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Missing or invalid CalDAV credentials")
+	})
+}
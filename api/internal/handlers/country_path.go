@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/cache"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/utils"
+)
+
+// CountryHolidays handles the progressive-path URL scheme
+// /holidays/{country}/{year}/{month}/{day}, where each additional path
+// segment tightens the filter and a trailing `.{format}` selects the
+// response encoding (json/ics/csv). Today {country} is pinned to "MY" by
+// the route pattern; making it a segment now means adding SG/BN later
+// won't break existing URLs.
+func CountryHolidays(w http.ResponseWriter, r *http.Request) {
+	var year, month, day int
+	var err error
+
+	if yearStr := chi.URLParam(r, "year"); yearStr != "" {
+		year, err = strconv.Atoi(yearStr)
+		if err != nil || len(yearStr) != 4 {
+			utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid year")
+			return
+		}
+	}
+
+	if monthStr := chi.URLParam(r, "month"); monthStr != "" {
+		month, err = strconv.Atoi(monthStr)
+		if err != nil || month < 1 || month > 12 {
+			utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid month")
+			return
+		}
+	}
+
+	if dayStr := chi.URLParam(r, "day"); dayStr != "" {
+		day, err = strconv.Atoi(dayStr)
+		if err != nil || day < 1 || day > 31 {
+			utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid day")
+			return
+		}
+	}
+
+	format := chi.URLParam(r, "format")
+	if format == "" {
+		// middleware.URLFormat strips the suffix before routing, so a
+		// suffixed request usually arrives here with no {format} param.
+		format, _ = r.Context().Value(middleware.URLFormatCtxKey).(string)
+	}
+	if format == "" {
+		format = "json"
+	}
+
+	stateCode := r.URL.Query().Get("state")
+	includeReplacements := r.URL.Query().Get("include_replacements") != "false"
+
+	var holidays []models.Holiday
+	var cached bool
+	if day > 0 {
+		date, dateErr := time.Parse("2006-01-02", fmt.Sprintf("%04d-%02d-%02d", year, month, day))
+		if dateErr != nil {
+			utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Date does not exist")
+			return
+		}
+		holidays, cached = cache.HolidaysForDate(date, stateCode)
+		if !cached {
+			holidays, err = db.GetHolidaysForDate(r.Context(), date, stateCode)
+		}
+	} else {
+		holidays, cached = cache.Holidays(year, stateCode, month, includeReplacements)
+		if !cached {
+			holidays, err = db.GetHolidays(r.Context(), year, stateCode, month, includeReplacements)
+		}
+	}
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
+		return
+	}
+
+	if holidays == nil {
+		holidays = []models.Holiday{}
+	}
+
+	switch format {
+	case "ics":
+		writeICSResponse(w, holidays, fmt.Sprintf("Malaysia Public Holidays - %s", stateCode))
+	case "csv":
+		writeCSVResponse(w, holidays)
+	case "json":
+		utils.RespondJSON(w, http.StatusOK, holidays)
+	default:
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Unsupported format")
+	}
+}
+
+// writeCSVResponse encodes holidays as CSV, for clients that want the
+// .csv long-tail URL suffix rather than JSON or an iCalendar feed.
+func writeCSVResponse(w http.ResponseWriter, holidays []models.Holiday) {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+
+	cw.Write([]string{"id", "name", "name_en", "date", "day_of_week", "type", "states"})
+	for _, h := range holidays {
+		nameEn := ""
+		if h.NameEn != nil {
+			nameEn = *h.NameEn
+		}
+		cw.Write([]string{
+			h.ID,
+			h.Name,
+			nameEn,
+			h.Date.Time.Format("2006-01-02"),
+			h.DayOfWeek,
+			string(h.Type),
+			fmt.Sprintf("%v", h.States),
+		})
+	}
+	cw.Flush()
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="holidays.csv"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
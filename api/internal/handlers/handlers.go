@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/cache"
 	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
 	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
 	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/utils"
@@ -47,26 +49,72 @@ func GetHolidays(w http.ResponseWriter, r *http.Request) {
 		includeReplacements = false
 	}
 
-	holidays, err := db.GetHolidays(r.Context(), year, stateCode, month, includeReplacements)
-	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
-		return
+	// Prefer the in-memory cache (CPU-bound, near-instant) and only fall
+	// back to Postgres if it hasn't been loaded yet or has nothing for the
+	// requested year.
+	holidays, cached := cache.Holidays(year, stateCode, month, includeReplacements)
+	if !cached {
+		holidays, err = db.GetHolidays(r.Context(), year, stateCode, month, includeReplacements)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
+			return
+		}
 	}
-	
+
 	if holidays == nil {
 	    holidays = []models.Holiday{}
 	}
 
+	if wantsICS(r) {
+		calName := "Malaysia Public Holidays"
+		if stateCode != "" {
+			calName = fmt.Sprintf("Malaysia Public Holidays - %s", stateCode)
+		}
+		writeICSResponse(w, holidays, calName)
+		return
+	}
+
+	limit := defaultPageLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, parseErr := strconv.Atoi(limitStr)
+		if parseErr != nil || l <= 0 || l > maxPageLimit {
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", fmt.Sprintf("limit must be between 1 and %d", maxPageLimit))
+			return
+		}
+		limit = l
+	}
+
+	page, err := paginateHolidays(holidays, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "Invalid cursor")
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("Link", linkHeaderValue(r.URL.Path, r.URL.RawQuery, page.NextCursor))
+	}
+
+	dataVersion := cache.DataVersion()
+	if dataVersion == "" {
+		dataVersion = "2024.1.0" // TODO: Fetch from DB metadata
+	}
+
 	meta := models.Meta{
 		TotalCount:  len(holidays),
 		GeneratedAt: time.Now(),
-		DataVersion: "2024.1.0", // TODO: Fetch from DB metadata
+		DataVersion: dataVersion,
+		NextCursor:  page.NextCursor,
+		PrevCursor:  page.PrevCursor,
 	}
 	if year > 0 {
 		meta.Year = &year
 	}
 
-	utils.RespondWithMeta(w, http.StatusOK, holidays, meta)
+	// RespondWithMetaCached derives the ETag from dataVersion + the query
+	// string (which includes cursor/limit), not from the response body -
+	// meta.GeneratedAt is a fresh timestamp on every call, so hashing the
+	// body itself would make If-None-Match never match.
+	utils.RespondWithMetaCached(w, r, http.StatusOK, page.Items, meta, dataVersion)
 }
 
 // GetHolidayByID handles GET /holidays/{id}
@@ -142,17 +190,27 @@ func GetStateHolidays(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Reuse GetHolidays logic but enforce state
-	holidays, err := db.GetHolidays(r.Context(), year, stateCode, 0, true)
-	if err != nil {
-		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
-		return
+	// Reuse GetHolidays logic but enforce state - prefer the cache, falling
+	// back to Postgres if it hasn't been loaded yet or has nothing for the
+	// requested year.
+	holidays, cached := cache.Holidays(year, stateCode, 0, true)
+	if !cached {
+		holidays, err = db.GetHolidays(r.Context(), year, stateCode, 0, true)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
+			return
+		}
 	}
-	
+
 	if holidays == nil {
 	    holidays = []models.Holiday{}
 	}
-	
+
+	if wantsICS(r) {
+		writeICSResponse(w, holidays, fmt.Sprintf("Malaysia Public Holidays - %s", stateCode))
+		return
+	}
+
 	// We also need state info for metadata
 	state, err := db.GetState(r.Context(), stateCode)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -147,7 +148,198 @@ func TestGetStateHolidays(t *testing.T) {
     r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
     GetStateHolidays(w, r)
-    
+
+    resp := w.Result()
+    assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestGetHolidaysPaginationRespectsLimit(t *testing.T) {
+    req := httptest.NewRequest("GET", "/holidays?year=2026&limit=2", nil)
+    w := httptest.NewRecorder()
+
+    GetHolidays(w, req)
+
+    resp := w.Result()
+    assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+    var apiResp models.APIResponse
+    json.NewDecoder(resp.Body).Decode(&apiResp)
+
+    dataBytes, _ := json.Marshal(apiResp.Data)
+    var holidays []models.Holiday
+    json.Unmarshal(dataBytes, &holidays)
+
+    assert.LessOrEqual(t, len(holidays), 2)
+    if len(holidays) == 2 {
+        assert.NotEmpty(t, apiResp.Meta.NextCursor)
+    }
+}
+
+func TestGetHolidaysByYear(t *testing.T) {
+    r := httptest.NewRequest("GET", "/holidays/2026", nil)
+    w := httptest.NewRecorder()
+
+    rctx := chi.NewRouteContext()
+    rctx.URLParams.Add("year", "2026")
+    r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+    GetHolidaysByYear(w, r)
+
+    resp := w.Result()
+    assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestCountryHolidaysByYearMonth(t *testing.T) {
+    r := httptest.NewRequest("GET", "/holidays/MY/2026/8", nil)
+    w := httptest.NewRecorder()
+
+    rctx := chi.NewRouteContext()
+    rctx.URLParams.Add("country", "MY")
+    rctx.URLParams.Add("year", "2026")
+    rctx.URLParams.Add("month", "8")
+    r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+    CountryHolidays(w, r)
+
+    resp := w.Result()
+    assert.Equal(t, http.StatusOK, resp.StatusCode)
+    assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+}
+
+func TestCountryHolidaysCSVFormat(t *testing.T) {
+    r := httptest.NewRequest("GET", "/holidays/MY/2026/8.csv", nil)
+    w := httptest.NewRecorder()
+
+    rctx := chi.NewRouteContext()
+    rctx.URLParams.Add("country", "MY")
+    rctx.URLParams.Add("year", "2026")
+    rctx.URLParams.Add("month", "8")
+    rctx.URLParams.Add("format", "csv")
+    r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+    CountryHolidays(w, r)
+
+    resp := w.Result()
+    assert.Equal(t, http.StatusOK, resp.StatusCode)
+    assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+}
+
+func TestCountryHolidaysInvalidMonth(t *testing.T) {
+    r := httptest.NewRequest("GET", "/holidays/MY/2026/13", nil)
+    w := httptest.NewRecorder()
+
+    rctx := chi.NewRouteContext()
+    rctx.URLParams.Add("country", "MY")
+    rctx.URLParams.Add("year", "2026")
+    rctx.URLParams.Add("month", "13")
+    r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+    CountryHolidays(w, r)
+
+    resp := w.Result()
+    assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGetHolidayRulesEmpty(t *testing.T) {
+    req := httptest.NewRequest("GET", "/holidays/rules", nil)
+    w := httptest.NewRecorder()
+
+    GetHolidayRules(w, req)
+
     resp := w.Result()
     assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+    var apiResp models.APIResponse
+    json.NewDecoder(resp.Body).Decode(&apiResp)
+    assert.True(t, apiResp.Success)
+}
+
+func TestCreateHolidayRuleRequiresAuth(t *testing.T) {
+    req := httptest.NewRequest("POST", "/holidays/rules", strings.NewReader(`{"id":"x","name":"x","rrule":"FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=1"}`))
+    w := httptest.NewRecorder()
+
+    CreateHolidayRule(w, req)
+
+    resp := w.Result()
+    assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestCreateHolidayRuleRequiresFields(t *testing.T) {
+    os.Setenv("ADMIN_API_KEY", "secret")
+    defer os.Unsetenv("ADMIN_API_KEY")
+
+    req := httptest.NewRequest("POST", "/holidays/rules", strings.NewReader(`{"name":"Missing ID"}`))
+    req.Header.Set("X-Api-Key", "secret")
+    w := httptest.NewRecorder()
+
+    CreateHolidayRule(w, req)
+
+    resp := w.Result()
+    assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateHolidayRuleRejectsInvalidRRule(t *testing.T) {
+    os.Setenv("ADMIN_API_KEY", "secret")
+    defer os.Unsetenv("ADMIN_API_KEY")
+
+    req := httptest.NewRequest("POST", "/holidays/rules", strings.NewReader(`{"id":"x","name":"x","rrule":"garbage"}`))
+    req.Header.Set("X-Api-Key", "secret")
+    w := httptest.NewRecorder()
+
+    CreateHolidayRule(w, req)
+
+    resp := w.Result()
+    assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCalDAVAuthRejectsMissingCredentials(t *testing.T) {
+    os.Setenv("CALDAV_API_KEY", "secret")
+    defer os.Unsetenv("CALDAV_API_KEY")
+
+    called := false
+    handler := CalDAVAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        called = true
+    }))
+
+    req := httptest.NewRequest("PROPFIND", "/caldav/federal/", nil)
+    w := httptest.NewRecorder()
+    handler.ServeHTTP(w, req)
+
+    assert.False(t, called)
+    assert.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+}
+
+func TestCalDAVAuthAcceptsAPIKeyHeader(t *testing.T) {
+    os.Setenv("CALDAV_API_KEY", "secret")
+    defer os.Unsetenv("CALDAV_API_KEY")
+
+    called := false
+    handler := CalDAVAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        called = true
+    }))
+
+    req := httptest.NewRequest("PROPFIND", "/caldav/federal/", nil)
+    req.Header.Set("X-Api-Key", "secret")
+    w := httptest.NewRecorder()
+    handler.ServeHTTP(w, req)
+
+    assert.True(t, called)
+    assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+}
+
+func TestGetHolidaysByDateInvalid(t *testing.T) {
+    // 31st of February doesn't exist - should 404 rather than 500.
+    r := httptest.NewRequest("GET", "/holidays/2026/02/31", nil)
+    w := httptest.NewRecorder()
+
+    rctx := chi.NewRouteContext()
+    rctx.URLParams.Add("year", "2026")
+    rctx.URLParams.Add("month", "02")
+    rctx.URLParams.Add("day", "31")
+    r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+    GetHolidaysByDate(w, r)
+
+    resp := w.Result()
+    assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 }
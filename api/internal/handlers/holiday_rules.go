@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/rules"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/utils"
+)
+
+// isAuthorizedAdmin checks the same API-key/basic-auth shape CalDAVAuth
+// uses, but fails closed: curating rules is a write path that can otherwise
+// be used to break GET /holidays for every caller (see CreateHolidayRule),
+// so an unset ADMIN_API_KEY denies rather than skips auth.
+func isAuthorizedAdmin(r *http.Request) bool {
+	key := os.Getenv("ADMIN_API_KEY")
+	if key == "" {
+		return false
+	}
+	if _, pass, ok := r.BasicAuth(); ok && pass == key {
+		return true
+	}
+	return r.Header.Get("X-Api-Key") == key
+}
+
+// GetHolidayRules handles GET /holidays/rules, listing the recurring-holiday
+// rules that db.GetHolidays falls back to for years with no gazetted rows.
+func GetHolidayRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := db.GetHolidayRules(r.Context())
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holiday rules")
+		return
+	}
+	if rules == nil {
+		rules = []models.HolidayRule{}
+	}
+
+	utils.RespondJSON(w, http.StatusOK, rules)
+}
+
+// CreateHolidayRule handles POST /holidays/rules. A gazette curator
+// registers a rule once instead of re-seeding it every year - it's
+// admin-only, enforced via isAuthorizedAdmin (an ADMIN_API_KEY must be
+// configured and presented), since a bad rule here breaks GET /holidays for
+// every caller in any future year with no pre-seeded rows.
+func CreateHolidayRule(w http.ResponseWriter, r *http.Request) {
+	if !isAuthorizedAdmin(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="admin"`)
+		utils.RespondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Missing or invalid admin credentials")
+		return
+	}
+
+	var rule models.HolidayRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	if rule.ID == "" || rule.Name == "" || rule.RRule == "" {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_REQUEST", "id, name and rrule are required")
+		return
+	}
+	if err := rules.ValidateRRule(rule.RRule); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if rule.ObservanceShift == "" {
+		rule.ObservanceShift = models.ObservanceNone
+	}
+
+	if err := db.CreateHolidayRule(r.Context(), rule); err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to create holiday rule")
+		return
+	}
+
+	utils.RespondJSON(w, http.StatusCreated, rule)
+}
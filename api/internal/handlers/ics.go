@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+)
+
+// foldICSLine folds a content line to a maximum of 75 octets as required by
+// RFC 5545 section 3.1, inserting a CRLF followed by a single leading space
+// before each continuation.
+func foldICSLine(line string) string {
+	const maxOctets = 75
+	if len(line) <= maxOctets {
+		return line + "\r\n"
+	}
+
+	var sb strings.Builder
+	remaining := line
+	// Continuation lines start with the folding space, which counts toward
+	// their 75 octets.
+	limit := maxOctets
+	for len(remaining) > limit {
+		sb.WriteString(remaining[:limit])
+		sb.WriteString("\r\n ")
+		remaining = remaining[limit:]
+		limit = maxOctets - 1
+	}
+	sb.WriteString(remaining)
+	sb.WriteString("\r\n")
+	return sb.String()
+}
+
+// escapeICSText escapes the reserved characters for ICS TEXT values per
+// RFC 5545 section 3.3.11 (backslash, semicolon, comma, newline).
+func escapeICSText(s string) string {
+	r := strings.NewReplacer(
+		"\\", "\\\\",
+		";", "\\;",
+		",", "\\,",
+		"\n", "\\n",
+	)
+	return r.Replace(s)
+}
+
+// buildVEvent renders a single holiday as an all-day VEVENT block.
+func buildVEvent(h models.Holiday) string {
+	var sb strings.Builder
+
+	start := h.Date.Time
+	end := start.AddDate(0, 0, 1)
+
+	summary := h.Name
+	if h.NameEn != nil && *h.NameEn != "" {
+		summary = fmt.Sprintf("%s (%s)", h.Name, *h.NameEn)
+	}
+
+	descParts := []string{}
+	if h.Description != nil && *h.Description != "" {
+		descParts = append(descParts, *h.Description)
+	}
+	if h.GazetteReference != nil && *h.GazetteReference != "" {
+		descParts = append(descParts, fmt.Sprintf("Gazette: %s", *h.GazetteReference))
+	}
+
+	categories := []string{string(h.Type)}
+	if h.Religion != nil && *h.Religion != "" {
+		categories = append(categories, string(*h.Religion))
+	}
+	categories = append(categories, h.States...)
+
+	sb.WriteString(foldICSLine("BEGIN:VEVENT"))
+	sb.WriteString(foldICSLine(fmt.Sprintf("UID:%s@malaysia-public-holiday-api", h.ID)))
+	sb.WriteString(foldICSLine(fmt.Sprintf("DTSTART;VALUE=DATE:%s", start.Format("20060102"))))
+	sb.WriteString(foldICSLine(fmt.Sprintf("DTEND;VALUE=DATE:%s", end.Format("20060102"))))
+	sb.WriteString(foldICSLine(fmt.Sprintf("SUMMARY:%s", escapeICSText(summary))))
+	if len(descParts) > 0 {
+		sb.WriteString(foldICSLine(fmt.Sprintf("DESCRIPTION:%s", escapeICSText(strings.Join(descParts, " - ")))))
+	}
+	sb.WriteString(foldICSLine(fmt.Sprintf("CATEGORIES:%s", escapeICSText(strings.Join(categories, ",")))))
+	// The schema doesn't track an updated_at on holidays yet, so the closest
+	// honest proxy for LAST-MODIFIED is the date the gazette change was
+	// declared, falling back to the holiday's own date.
+	lastModified := start
+	if h.DeclaredDate != nil {
+		lastModified = h.DeclaredDate.Time
+	}
+	sb.WriteString(foldICSLine(fmt.Sprintf("LAST-MODIFIED:%s", lastModified.UTC().Format("20060102T150405Z"))))
+	sb.WriteString(foldICSLine("END:VEVENT"))
+
+	return sb.String()
+}
+
+// buildICSCalendar wraps a set of holidays in a VCALENDAR envelope.
+func buildICSCalendar(holidays []models.Holiday, calName string) string {
+	var sb strings.Builder
+
+	sb.WriteString(foldICSLine("BEGIN:VCALENDAR"))
+	sb.WriteString(foldICSLine("VERSION:2.0"))
+	sb.WriteString(foldICSLine("PRODID:-//malaysia-public-holiday-api//EN"))
+	sb.WriteString(foldICSLine("CALSCALE:GREGORIAN"))
+	if calName != "" {
+		sb.WriteString(foldICSLine(fmt.Sprintf("X-WR-CALNAME:%s", escapeICSText(calName))))
+	}
+
+	for _, h := range holidays {
+		sb.WriteString(buildVEvent(h))
+	}
+
+	sb.WriteString(foldICSLine("END:VCALENDAR"))
+
+	return sb.String()
+}
+
+// wantsICS reports whether the request asked for an iCalendar response via
+// `?format=ics`, a `.ics` path suffix (as saved by middleware.URLFormat), or
+// an `Accept: text/calendar` header, so the existing JSON listing endpoints
+// can serve calendar clients without a separate URL.
+func wantsICS(r *http.Request) bool {
+	if r.URL.Query().Get("format") == "ics" {
+		return true
+	}
+	if format, _ := r.Context().Value(middleware.URLFormatCtxKey).(string); format == "ics" {
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/calendar")
+}
+
+// writeICSResponse sends holidays as an iCalendar feed with the given
+// calendar name.
+func writeICSResponse(w http.ResponseWriter, holidays []models.Holiday, calName string) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="holidays.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(buildICSCalendar(holidays, calName)))
+}
+
+// parseHolidayFilters reads the year/state/include_replacements query params
+// shared by the JSON and ICS holiday listing endpoints.
+func parseHolidayFilters(r *http.Request) (year int, stateCode string, includeReplacements bool, err error) {
+	yearStr := r.URL.Query().Get("year")
+	stateCode = r.URL.Query().Get("state")
+	includeReplacementsStr := r.URL.Query().Get("include_replacements")
+
+	if yearStr != "" {
+		year, err = strconv.Atoi(yearStr)
+		if err != nil {
+			return 0, "", false, err
+		}
+	}
+
+	includeReplacements = true
+	if includeReplacementsStr == "false" {
+		includeReplacements = false
+	}
+
+	return year, stateCode, includeReplacements, nil
+}
+
+// GetHolidaysICS handles GET /holidays.ics, serving the full (optionally
+// filtered) holiday list as an iCalendar feed so clients can subscribe from
+// Google Calendar, Apple Calendar, or any CalDAV-aware app.
+func GetHolidaysICS(w http.ResponseWriter, r *http.Request) {
+	year, stateCode, includeReplacements, err := parseHolidayFilters(r)
+	if err != nil {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	holidays, err := db.GetHolidays(r.Context(), year, stateCode, 0, includeReplacements)
+	if err != nil {
+		http.Error(w, "Failed to fetch holidays", http.StatusInternalServerError)
+		return
+	}
+
+	calName := "Malaysia Public Holidays"
+	if stateCode != "" {
+		calName = fmt.Sprintf("Malaysia Public Holidays - %s", stateCode)
+	}
+
+	writeICSResponse(w, holidays, calName)
+}
+
+// GetStateHolidaysICS handles GET /states/{state_code}/holidays.ics
+func GetStateHolidaysICS(w http.ResponseWriter, r *http.Request) {
+	stateCode := chi.URLParam(r, "state_code")
+
+	year, _, includeReplacements, err := parseHolidayFilters(r)
+	if err != nil {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	holidays, err := db.GetHolidays(r.Context(), year, stateCode, 0, includeReplacements)
+	if err != nil {
+		http.Error(w, "Failed to fetch holidays", http.StatusInternalServerError)
+		return
+	}
+
+	writeICSResponse(w, holidays, fmt.Sprintf("Malaysia Public Holidays - %s", stateCode))
+}
+
+// GetHolidaysByYearICS handles GET /holidays/{year}.ics
+func GetHolidaysByYearICS(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil {
+		http.Error(w, "Invalid year", http.StatusNotFound)
+		return
+	}
+
+	stateCode := r.URL.Query().Get("state")
+	includeReplacements := r.URL.Query().Get("include_replacements") != "false"
+
+	holidays, err := db.GetHolidays(r.Context(), year, stateCode, 0, includeReplacements)
+	if err != nil {
+		http.Error(w, "Failed to fetch holidays", http.StatusInternalServerError)
+		return
+	}
+
+	calName := fmt.Sprintf("Malaysia Public Holidays %d", year)
+	if stateCode != "" {
+		calName = fmt.Sprintf("%s - %s", calName, stateCode)
+	}
+
+	writeICSResponse(w, holidays, calName)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/metrics"
+)
+
+// MetricsMiddleware records per-route request counts and latency histograms
+// for every request that passes through the router. It relies on chi's
+// route context to get the matched pattern (e.g. "/holidays/{id}") rather
+// than the raw path, so metrics don't explode into one series per holiday ID.
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		route := r.URL.Path
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		metrics.RequestsTotal.WithLabelValues(route, r.Method, strconv.Itoa(status)).Inc()
+		metrics.RequestDuration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+	})
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+)
+
+// errInvalidCursor is returned for a cursor that doesn't decode to a
+// (date, id) pair.
+var errInvalidCursor = errors.New("invalid cursor")
+
+const defaultPageLimit = 50
+const maxPageLimit = 200
+
+// encodeCursor packs a holiday's (date, id) into an opaque, URL-safe
+// cursor. Encoding the sort key rather than an offset keeps cursors stable
+// across inserts/deletes elsewhere in the result set.
+func encodeCursor(h models.Holiday) string {
+	raw := h.Date.Time.Format("2006-01-02") + "|" + h.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor reverses encodeCursor.
+func decodeCursor(s string) (date time.Time, id string, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return time.Time{}, "", errInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return time.Time{}, "", errInvalidCursor
+	}
+
+	date, err = time.Parse("2006-01-02", parts[0])
+	if err != nil {
+		return time.Time{}, "", errInvalidCursor
+	}
+
+	return date, parts[1], nil
+}
+
+// holidayPage is one page of a cursor-paginated holiday listing.
+type holidayPage struct {
+	Items      []models.Holiday
+	NextCursor string
+	PrevCursor string
+}
+
+// paginateHolidays sorts holidays into a stable (date, id) order and
+// returns the page starting just after cursorStr (or the first page if
+// cursorStr is empty), along with next/prev cursors for the JSON:API-style
+// `meta`/`Link` pagination.
+func paginateHolidays(holidays []models.Holiday, cursorStr string, limit int) (holidayPage, error) {
+	sorted := make([]models.Holiday, len(holidays))
+	copy(sorted, holidays)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].Date.Time.Equal(sorted[j].Date.Time) {
+			return sorted[i].Date.Time.Before(sorted[j].Date.Time)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	startIdx := 0
+	if cursorStr != "" {
+		cursorDate, cursorID, err := decodeCursor(cursorStr)
+		if err != nil {
+			return holidayPage{}, err
+		}
+		startIdx = sort.Search(len(sorted), func(i int) bool {
+			h := sorted[i]
+			if h.Date.Time.Equal(cursorDate) {
+				return h.ID > cursorID
+			}
+			return h.Date.Time.After(cursorDate)
+		})
+	}
+
+	endIdx := startIdx + limit
+	if endIdx > len(sorted) {
+		endIdx = len(sorted)
+	}
+	page := sorted[startIdx:endIdx]
+
+	var nextCursor, prevCursor string
+	if endIdx < len(sorted) {
+		nextCursor = encodeCursor(sorted[endIdx-1])
+	}
+	if startIdx > 0 {
+		prevAnchor := startIdx - limit - 1
+		if prevAnchor >= 0 {
+			prevCursor = encodeCursor(sorted[prevAnchor])
+		}
+	}
+
+	return holidayPage{Items: page, NextCursor: nextCursor, PrevCursor: prevCursor}, nil
+}
+
+// linkHeaderValue renders the rel="next" Link header for a paginated
+// response, reusing the request path and query string with cursor swapped.
+func linkHeaderValue(basePath, rawQuery, nextCursor string) string {
+	q := strings.Split(rawQuery, "&")
+	filtered := q[:0]
+	for _, pair := range q {
+		if pair == "" || strings.HasPrefix(pair, "cursor=") {
+			continue
+		}
+		filtered = append(filtered, pair)
+	}
+	filtered = append(filtered, "cursor="+nextCursor)
+
+	return fmt.Sprintf(`<%s?%s>; rel="next"`, basePath, strings.Join(filtered, "&"))
+}
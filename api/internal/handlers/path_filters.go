@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/utils"
+)
+
+// parsePathYearMonth pulls the {year}/{month} chi URL params, already
+// constrained to digits by the route pattern, and validates them as a real
+// calendar year/month.
+func parsePathYearMonth(r *http.Request) (year, month int, ok bool) {
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil || year < 1 {
+		return 0, 0, false
+	}
+
+	monthStr := chi.URLParam(r, "month")
+	if monthStr == "" {
+		return year, 0, true
+	}
+
+	month, err = strconv.Atoi(monthStr)
+	if err != nil || month < 1 || month > 12 {
+		return 0, 0, false
+	}
+
+	return year, month, true
+}
+
+// GetHolidaysByYear handles GET /holidays/{year}
+func GetHolidaysByYear(w http.ResponseWriter, r *http.Request) {
+	year, _, ok := parsePathYearMonth(r)
+	if !ok {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid year")
+		return
+	}
+
+	// GET /holidays/{year}.ics arrives here with its suffix stripped by
+	// middleware.URLFormat.
+	if wantsICS(r) {
+		GetHolidaysByYearICS(w, r)
+		return
+	}
+
+	respondHolidaysForYearMonth(w, r, year, 0, "")
+}
+
+// GetHolidaysByYearMonth handles GET /holidays/{year}/{month}
+func GetHolidaysByYearMonth(w http.ResponseWriter, r *http.Request) {
+	year, month, ok := parsePathYearMonth(r)
+	if !ok {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid year/month")
+		return
+	}
+
+	respondHolidaysForYearMonth(w, r, year, month, "")
+}
+
+// GetHolidaysByDate handles GET /holidays/{year}/{month}/{day}
+func GetHolidaysByDate(w http.ResponseWriter, r *http.Request) {
+	_, _, ok := parsePathYearMonth(r)
+	if !ok {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid year/month")
+		return
+	}
+
+	day, err := strconv.Atoi(chi.URLParam(r, "day"))
+	if err != nil || day < 1 || day > 31 {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid day")
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", chi.URLParam(r, "year")+"-"+chi.URLParam(r, "month")+"-"+chi.URLParam(r, "day"))
+	if err != nil {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Date does not exist")
+		return
+	}
+
+	stateCode := r.URL.Query().Get("state")
+	holidays, err := db.GetHolidaysForDate(r.Context(), date, stateCode)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
+		return
+	}
+
+	if holidays == nil {
+		holidays = []models.Holiday{}
+	}
+
+	utils.RespondJSON(w, http.StatusOK, holidays)
+}
+
+// GetStateHolidaysByYear handles GET /states/{state_code}/holidays/{year}
+func GetStateHolidaysByYear(w http.ResponseWriter, r *http.Request) {
+	year, _, ok := parsePathYearMonth(r)
+	if !ok {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid year")
+		return
+	}
+
+	respondHolidaysForYearMonth(w, r, year, 0, chi.URLParam(r, "state_code"))
+}
+
+// GetStateHolidaysByYearMonth handles GET /states/{state_code}/holidays/{year}/{month}
+func GetStateHolidaysByYearMonth(w http.ResponseWriter, r *http.Request) {
+	year, month, ok := parsePathYearMonth(r)
+	if !ok {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid year/month")
+		return
+	}
+
+	respondHolidaysForYearMonth(w, r, year, month, chi.URLParam(r, "state_code"))
+}
+
+// GetStateHolidaysByDate handles GET /states/{state_code}/holidays/{year}/{month}/{day}
+func GetStateHolidaysByDate(w http.ResponseWriter, r *http.Request) {
+	stateCode := chi.URLParam(r, "state_code")
+
+	_, _, ok := parsePathYearMonth(r)
+	if !ok {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid year/month")
+		return
+	}
+
+	day, err := strconv.Atoi(chi.URLParam(r, "day"))
+	if err != nil || day < 1 || day > 31 {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Invalid day")
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", chi.URLParam(r, "year")+"-"+chi.URLParam(r, "month")+"-"+chi.URLParam(r, "day"))
+	if err != nil {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "Date does not exist")
+		return
+	}
+
+	holidays, err := db.GetHolidaysForDate(r.Context(), date, stateCode)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
+		return
+	}
+
+	if holidays == nil {
+		holidays = []models.Holiday{}
+	}
+
+	utils.RespondJSON(w, http.StatusOK, holidays)
+}
+
+// respondHolidaysForYearMonth runs the shared GetHolidays query for the
+// {year}/{month} path-filtered routes, honoring the existing `state` and
+// `include_replacements` query params as overrides on top of the path filter.
+func respondHolidaysForYearMonth(w http.ResponseWriter, r *http.Request, year, month int, pathStateCode string) {
+	stateCode := pathStateCode
+	if stateCode == "" {
+		stateCode = r.URL.Query().Get("state")
+	}
+
+	includeReplacements := true
+	if r.URL.Query().Get("include_replacements") == "false" {
+		includeReplacements = false
+	}
+
+	holidays, err := db.GetHolidays(r.Context(), year, stateCode, month, includeReplacements)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
+		return
+	}
+
+	if holidays == nil {
+		holidays = []models.Holiday{}
+	}
+
+	meta := models.Meta{
+		TotalCount:  len(holidays),
+		GeneratedAt: time.Now(),
+		DataVersion: "2024.1.0",
+	}
+	meta.Year = &year
+
+	utils.RespondWithMeta(w, http.StatusOK, holidays, meta)
+}
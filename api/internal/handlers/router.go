@@ -1,9 +1,12 @@
 package handlers
 
 import (
+    "net/http"
+
     "github.com/go-chi/chi/v5"
     "github.com/go-chi/chi/v5/middleware"
     "github.com/go-chi/cors"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // NewRouter returns a new HTTP router
@@ -14,6 +17,7 @@ func NewRouter() *chi.Mux {
     r.Use(middleware.Logger)
     r.Use(middleware.Recoverer)
     r.Use(middleware.URLFormat)
+    r.Use(MetricsMiddleware)
 
     // CORS
     r.Use(cors.Handler(cors.Options{
@@ -38,17 +42,76 @@ func NewRouter() *chi.Mux {
         r.Get("/upcoming", GetUpcomingHolidays)
         r.Get("/check", CheckHoliday)
         r.Get("/working-days", CalculateWorkingDays)
+        r.Get("/next-working-day", NextWorkingDay)
+        r.Get("/add-working-days", AddWorkingDays)
+        r.Get("/shift", ShiftDate)
+        r.Get("/rules", GetHolidayRules)
+        r.Post("/rules", CreateHolidayRule) // admin-only, see CreateHolidayRule
         r.Get("/", GetHolidays) // /holidays
+
+        // Path-based year/month/day filters. Constrain these to digits so
+        // they don't shadow /holidays/{id} below - {id} values aren't
+        // purely numeric, so chi's regex-matched routes take priority here.
+        r.Get("/{year:[0-9]{4}}.ics", GetHolidaysByYearICS)
+        r.Get("/{year:[0-9]{4}}", GetHolidaysByYear)
+        r.Get("/{year:[0-9]{4}}/{month:[0-9]{1,2}}", GetHolidaysByYearMonth)
+        r.Get("/{year:[0-9]{4}}/{month:[0-9]{1,2}}/{day:[0-9]{1,2}}", GetHolidaysByDate)
+
+        // Progressive /holidays/MY/... path, country pinned to "MY" for now
+        // (the {country:MY} regex, not a generic {country}, is what keeps
+        // this from swallowing /holidays/{id} below). Each extra segment
+        // narrows the filter, and a trailing .{format} picks the encoding -
+        // the unsuffixed routes default to JSON. middleware.URLFormat above
+        // strips the suffix before routing, so suffixed requests land on the
+        // unsuffixed patterns and CountryHolidays reads the format it saved.
+        r.Route("/{country:MY}", func(r chi.Router) {
+            r.Get("/", CountryHolidays)
+            r.Get("/{year:[0-9]{4}}", CountryHolidays)
+            r.Get("/{year:[0-9]{4}}.{format:ics|csv|json}", CountryHolidays)
+            r.Get("/{year:[0-9]{4}}/{month:[0-9]{1,2}}", CountryHolidays)
+            r.Get("/{year:[0-9]{4}}/{month:[0-9]{1,2}}.{format:ics|csv|json}", CountryHolidays)
+            r.Get("/{year:[0-9]{4}}/{month:[0-9]{1,2}}/{day:[0-9]{1,2}}", CountryHolidays)
+            r.Get("/{year:[0-9]{4}}/{month:[0-9]{1,2}}/{day:[0-9]{1,2}}.{format:ics|csv|json}", CountryHolidays)
+        })
+
         r.Get("/{id}", GetHolidayByID)
     })
 
     r.Route("/states", func(r chi.Router) {
         r.Get("/", GetStates)
         r.Get("/{state_code}/holidays", GetStateHolidays)
+        r.Get("/{state_code}/holidays.ics", GetStateHolidaysICS)
+        r.Get("/{state_code}/holidays/{year:[0-9]{4}}", GetStateHolidaysByYear)
+        r.Get("/{state_code}/holidays/{year:[0-9]{4}}/{month:[0-9]{1,2}}", GetStateHolidaysByYearMonth)
+        r.Get("/{state_code}/holidays/{year:[0-9]{4}}/{month:[0-9]{1,2}}/{day:[0-9]{1,2}}", GetStateHolidaysByDate)
         r.Get("/{state_code}/weekend", GetStateWeekend)
     })
-    
+
     r.Get("/metadata", GetMetadata)
+    r.Get("/holidays.ics", GetHolidaysICS)
+    r.Post("/availability", GetAvailability)
+    r.Handle("/metrics", promhttp.Handler())
+
+    r.Route("/admin", func(r chi.Router) {
+        r.Post("/reload", AdminReloadCache)
+    })
+
+    // CalDAV surface so Thunderbird/iOS/macOS Calendar can subscribe with
+    // auto-refresh and server-side time-range queries instead of
+    // re-downloading the whole .ics feed. r.Handle is method-agnostic in
+    // chi, which is what lets PROPFIND/REPORT reach CalDAV through here.
+    r.Route("/caldav", func(r chi.Router) {
+        r.Use(CalDAVAuth)
+        r.Handle("/{calendar_id}/*", http.HandlerFunc(CalDAV))
+    })
+
+    // v2 namespaces everything under a country code so Malaysia is one
+    // CountryProvider among many rather than the only tenant. The existing
+    // unprefixed /holidays, /states, etc. routes above remain as aliases.
+    r.Route("/v2/{country}", func(r chi.Router) {
+        r.Get("/holidays", V2GetHolidays)
+        r.Get("/states", V2GetStates)
+    })
 
     return r
 }
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/providers"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/utils"
+)
+
+// requireKnownCountry responds 501 Not Implemented unless {country} names a registered
+// providers.CountryProvider, so /v2 routes fail fast for countries that
+// don't have a provider (and seed data) yet rather than silently returning
+// an empty Malaysia-shaped response.
+func requireKnownCountry(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		country := chi.URLParam(r, "country")
+		if _, ok := providers.Get(country); !ok {
+			utils.RespondError(w, http.StatusNotImplemented, "COUNTRY_NOT_SUPPORTED", "No data provider registered for this country yet")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// V2GetHolidays handles GET /v2/{country}/holidays. Today only MY is
+// registered, so this aliases straight onto GetHolidays; once a second
+// CountryProvider lands, this is where per-country routing grows.
+var V2GetHolidays = requireKnownCountry(GetHolidays)
+
+// V2GetStates handles GET /v2/{country}/states
+var V2GetStates = requireKnownCountry(GetStates)
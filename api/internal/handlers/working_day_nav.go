@@ -0,0 +1,379 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/utils"
+)
+
+// weekendOverrides maps the `weekend_override` query param to the set of
+// weekday names it stands for, for callers whose company policy differs
+// from the gazetted state weekend (e.g. a Friday-Saturday weekend office).
+var weekendOverrides = map[string][]string{
+	"SAT_SUN": {"Saturday", "Sunday"},
+	"FRI_SAT": {"Friday", "Saturday"},
+	"SUN":     {"Sunday"},
+}
+
+// resolveWeekendSet returns the weekend-days set to use: the override if
+// one was given, otherwise the state's own WeekendDays.
+func resolveWeekendSet(override string, stateWeekendDays []string) (map[string]bool, bool) {
+	days := stateWeekendDays
+	if override != "" {
+		overrideDays, ok := weekendOverrides[override]
+		if !ok {
+			return nil, false
+		}
+		days = overrideDays
+	}
+
+	set := make(map[string]bool, len(days))
+	for _, d := range days {
+		set[d] = true
+	}
+	return set, true
+}
+
+// parseExcludeHolidayIDs splits a comma-separated `exclude_holiday_ids`
+// query param into a lookup set.
+func parseExcludeHolidayIDs(s string) map[string]bool {
+	if s == "" {
+		return nil
+	}
+	ids := make(map[string]bool)
+	for _, id := range strings.Split(s, ",") {
+		id = strings.TrimSpace(id)
+		if id != "" {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// buildHolidayMap fetches holidays in [start, end] for stateCode and
+// returns a date lookup map keyed by formatted date (matching
+// CalculateWorkingDays' convention, and avoiding time.Time equality
+// mismatches between Postgres-scanned and time.Parse-derived values),
+// skipping any holiday whose ID is in excludeIDs so callers can ignore
+// specific holidays (e.g. ones their company doesn't observe).
+func buildHolidayMap(r *http.Request, start, end time.Time, stateCode string, excludeIDs map[string]bool) (map[string]bool, error) {
+	if len(excludeIDs) == 0 {
+		m, err := db.GetHolidaysInRangeMap(r.Context(), start, end, stateCode)
+		return m, err
+	}
+
+	holidays, err := db.GetHolidaysInRange(r.Context(), start, end, stateCode)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]bool, len(holidays))
+	for _, h := range holidays {
+		if excludeIDs[h.ID] {
+			continue
+		}
+		m[h.Date.Time.Format("2006-01-02")] = true
+	}
+	return m, nil
+}
+
+// skippedDay describes a non-working day that was stepped over while
+// navigating to the next working day.
+type skippedDay struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"` // "weekend" or "holiday"
+}
+
+// maxWorkingDayWalk caps how many working days a single request may walk
+// (via `offset`, `days`, or `business_days`). Without a cap, an arbitrarily
+// large value forces a day-by-day walk of unbounded length per request;
+// ~20 working years is generous for any realistic SLA/payroll calculation.
+const maxWorkingDayWalk = 5000
+
+// holidayLookupSpan returns the [start, end] window buildHolidayMap should
+// fetch for a walk of up to walk working days from `from`, in either
+// direction. It has to cover at least `walk` calendar days past the
+// boundary in the direction of travel - a run of consecutive
+// holidays/weekends can only ever skip, never shrink, the calendar span a
+// given number of working days spans - so walk is doubled as a cushion,
+// with a one-year floor so small walks keep the "long weekend chains"
+// margin the original fixed ±1-year window gave every request.
+func holidayLookupSpan(from time.Time, walk int) (start, end time.Time) {
+	if walk < 0 {
+		walk = -walk
+	}
+	padDays := walk * 2
+	if padDays < 366 {
+		padDays = 366
+	}
+	return from.AddDate(0, 0, -padDays), from.AddDate(0, 0, padDays)
+}
+
+// walkWorkingDays steps day-by-day from `from` toward `offset` working days
+// (positive = forward, negative = backward), skipping weekends per
+// weekendSet and holidays per holidayMap (keyed by formatted date). It
+// returns the landed-on date and the list of non-working days skipped along
+// the way.
+func walkWorkingDays(from time.Time, offset int, weekendSet map[string]bool, holidayMap map[string]bool) (time.Time, []skippedDay) {
+	step := 1
+	if offset < 0 {
+		step = -1
+		offset = -offset
+	}
+
+	var skipped []skippedDay
+	curr := from
+	remaining := offset
+
+	for remaining > 0 {
+		curr = curr.AddDate(0, 0, step)
+
+		dayName := curr.Format("Monday")
+		isWeekend := weekendSet[dayName]
+		isHoliday := holidayMap[curr.Format("2006-01-02")]
+
+		if isWeekend || isHoliday {
+			reason := "weekend"
+			if isHoliday {
+				reason = "holiday"
+			}
+			skipped = append(skipped, skippedDay{Date: curr.Format("2006-01-02"), Reason: reason})
+			continue
+		}
+
+		remaining--
+	}
+
+	return curr, skipped
+}
+
+// NextWorkingDay handles GET /holidays/next-working-day, returning the Nth
+// working day from a given date. A negative `offset` walks backward, which
+// covers SLA-style "N working days before" deadline computations as well as
+// the forward "T+N business days" case.
+func NextWorkingDay(w http.ResponseWriter, r *http.Request) {
+	dateStr := r.URL.Query().Get("date")
+	stateCode := r.URL.Query().Get("state")
+	offsetStr := r.URL.Query().Get("offset")
+	weekendOverride := r.URL.Query().Get("weekend_override")
+	excludeIDs := parseExcludeHolidayIDs(r.URL.Query().Get("exclude_holiday_ids"))
+
+	if dateStr == "" || stateCode == "" {
+		utils.RespondError(w, http.StatusBadRequest, "MISSING_PARAMETER", "date and state are required")
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "Invalid date format (YYYY-MM-DD)")
+		return
+	}
+
+	offset := 1
+	if offsetStr != "" {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset == 0 {
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "offset must be a non-zero integer")
+			return
+		}
+		if offset > maxWorkingDayWalk || offset < -maxWorkingDayWalk {
+			utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", fmt.Sprintf("offset must be between -%d and %d", maxWorkingDayWalk, maxWorkingDayWalk))
+			return
+		}
+	}
+
+	state, err := db.GetState(r.Context(), stateCode)
+	if err != nil || state == nil {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "State not found")
+		return
+	}
+
+	weekendSet, ok := resolveWeekendSet(weekendOverride, state.WeekendDays)
+	if !ok {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "weekend_override must be one of SAT_SUN, FRI_SAT, SUN")
+		return
+	}
+
+	spanStart, spanEnd := holidayLookupSpan(from, offset)
+
+	holidayMap, err := buildHolidayMap(r, spanStart, spanEnd, stateCode, excludeIDs)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
+		return
+	}
+
+	result, skipped := walkWorkingDays(from, offset, weekendSet, holidayMap)
+
+	data := struct {
+		FromDate string       `json:"from_date"`
+		StateCode string      `json:"state_code"`
+		Offset   int          `json:"offset"`
+		Date     string       `json:"date"`
+		Skipped  []skippedDay `json:"skipped"`
+	}{
+		FromDate:  dateStr,
+		StateCode: stateCode,
+		Offset:    offset,
+		Date:      result.Format("2006-01-02"),
+		Skipped:   skipped,
+	}
+
+	utils.RespondJSON(w, http.StatusOK, data)
+}
+
+// AddWorkingDays handles GET /holidays/add-working-days, returning the date
+// `days` business days after `start_date`. `direction=backward` walks
+// toward the past instead, covering SLA-style "N working days before a due
+// date" deadline computations with the same endpoint.
+func AddWorkingDays(w http.ResponseWriter, r *http.Request) {
+	startDateStr := r.URL.Query().Get("start_date")
+	stateCode := r.URL.Query().Get("state")
+	daysStr := r.URL.Query().Get("days")
+	weekendOverride := r.URL.Query().Get("weekend_override")
+	direction := r.URL.Query().Get("direction")
+	excludeIDs := parseExcludeHolidayIDs(r.URL.Query().Get("exclude_holiday_ids"))
+
+	if startDateStr == "" || stateCode == "" || daysStr == "" {
+		utils.RespondError(w, http.StatusBadRequest, "MISSING_PARAMETER", "start_date, days, and state are required")
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "Invalid start_date")
+		return
+	}
+
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days < 0 {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "days must be a non-negative integer")
+		return
+	}
+	if days > maxWorkingDayWalk {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", fmt.Sprintf("days must not exceed %d", maxWorkingDayWalk))
+		return
+	}
+
+	if direction == "backward" {
+		days = -days
+	} else if direction != "" && direction != "forward" {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "direction must be forward or backward")
+		return
+	}
+
+	state, err := db.GetState(r.Context(), stateCode)
+	if err != nil || state == nil {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "State not found")
+		return
+	}
+
+	weekendSet, ok := resolveWeekendSet(weekendOverride, state.WeekendDays)
+	if !ok {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "weekend_override must be one of SAT_SUN, FRI_SAT, SUN")
+		return
+	}
+
+	spanStart, spanEnd := holidayLookupSpan(from, days)
+
+	holidayMap, err := buildHolidayMap(r, spanStart, spanEnd, stateCode, excludeIDs)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
+		return
+	}
+
+	result, skipped := walkWorkingDays(from, days, weekendSet, holidayMap)
+
+	data := struct {
+		StartDate string       `json:"start_date"`
+		StateCode string       `json:"state_code"`
+		Days      int          `json:"days"`
+		Direction string       `json:"direction"`
+		Date      string       `json:"date"`
+		Skipped   []skippedDay `json:"skipped"`
+	}{
+		StartDate: startDateStr,
+		StateCode: stateCode,
+		Days:      days,
+		Direction: direction,
+		Date:      result.Format("2006-01-02"),
+		Skipped:   skipped,
+	}
+	if data.Direction == "" {
+		data.Direction = "forward"
+	}
+
+	utils.RespondJSON(w, http.StatusOK, data)
+}
+
+// ShiftDate handles GET /holidays/shift, moving `business_days` working days
+// from `date` and reporting which holidays/weekends were skipped along the
+// way. This is the same walk as NextWorkingDay, exposed under the name the
+// payroll/SLA use case tends to reach for.
+func ShiftDate(w http.ResponseWriter, r *http.Request) {
+	dateStr := r.URL.Query().Get("date")
+	stateCode := r.URL.Query().Get("state")
+	businessDaysStr := r.URL.Query().Get("business_days")
+
+	if dateStr == "" || stateCode == "" || businessDaysStr == "" {
+		utils.RespondError(w, http.StatusBadRequest, "MISSING_PARAMETER", "date, state, and business_days are required")
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "Invalid date format (YYYY-MM-DD)")
+		return
+	}
+
+	businessDays, err := strconv.Atoi(businessDaysStr)
+	if err != nil || businessDays == 0 {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "business_days must be a non-zero integer")
+		return
+	}
+	if businessDays > maxWorkingDayWalk || businessDays < -maxWorkingDayWalk {
+		utils.RespondError(w, http.StatusBadRequest, "INVALID_PARAMETER", fmt.Sprintf("business_days must be between -%d and %d", maxWorkingDayWalk, maxWorkingDayWalk))
+		return
+	}
+
+	state, err := db.GetState(r.Context(), stateCode)
+	if err != nil || state == nil {
+		utils.RespondError(w, http.StatusNotFound, "NOT_FOUND", "State not found")
+		return
+	}
+
+	spanStart, spanEnd := holidayLookupSpan(from, businessDays)
+
+	holidayMap, err := db.GetHolidaysInRangeMap(r.Context(), spanStart, spanEnd, stateCode)
+	if err != nil {
+		utils.RespondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to fetch holidays")
+		return
+	}
+
+	weekendSet := make(map[string]bool)
+	for _, d := range state.WeekendDays {
+		weekendSet[d] = true
+	}
+
+	result, skipped := walkWorkingDays(from, businessDays, weekendSet, holidayMap)
+
+	data := struct {
+		FromDate     string       `json:"from_date"`
+		StateCode    string       `json:"state_code"`
+		BusinessDays int          `json:"business_days"`
+		ResultDate   string       `json:"result_date"`
+		Skipped      []skippedDay `json:"skipped"`
+	}{
+		FromDate:     dateStr,
+		StateCode:    stateCode,
+		BusinessDays: businessDays,
+		ResultDate:   result.Format("2006-01-02"),
+		Skipped:      skipped,
+	}
+
+	utils.RespondJSON(w, http.StatusOK, data)
+}
@@ -0,0 +1,91 @@
+// Package metrics exposes Prometheus instrumentation for the API: per-route
+// request counters and latency histograms, and a collector that samples the
+// pgxpool connection pool on every scrape.
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// RequestsTotal counts HTTP requests by route pattern, method, and status code.
+	RequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "holiday_api_http_requests_total",
+			Help: "Total number of HTTP requests processed, labeled by route, method, and status code.",
+		},
+		[]string{"route", "method", "status"},
+	)
+
+	// RequestDuration tracks request latency in seconds by route and method.
+	RequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "holiday_api_http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds, labeled by route and method.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"route", "method"},
+	)
+
+	// DBQueryDuration tracks the latency of individual db package queries.
+	DBQueryDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "holiday_api_db_query_duration_seconds",
+			Help:    "Database query latency in seconds, labeled by query name.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"query"},
+	)
+)
+
+// poolStatsCollector samples a pgxpool connection pool on every scrape so
+// its acquired/idle/max connection counts and wait time show up alongside
+// the request metrics without needing a periodic background sampler. It
+// takes the pool directly rather than importing internal/db, since db
+// already imports this package for DBQueryDuration and that would be an
+// import cycle.
+type poolStatsCollector struct {
+	pool     *pgxpool.Pool
+	acquired *prometheus.Desc
+	idle     *prometheus.Desc
+	max      *prometheus.Desc
+	waitTime *prometheus.Desc
+}
+
+func newPoolStatsCollector(pool *pgxpool.Pool) *poolStatsCollector {
+	return &poolStatsCollector{
+		pool:     pool,
+		acquired: prometheus.NewDesc("holiday_api_db_pool_acquired_conns", "Number of connections currently acquired from the pool.", nil, nil),
+		idle:     prometheus.NewDesc("holiday_api_db_pool_idle_conns", "Number of idle connections in the pool.", nil, nil),
+		max:      prometheus.NewDesc("holiday_api_db_pool_max_conns", "Maximum number of connections the pool will open.", nil, nil),
+		waitTime: prometheus.NewDesc("holiday_api_db_pool_wait_duration_seconds_total", "Cumulative time spent waiting for a connection to become available.", nil, nil),
+	}
+}
+
+func (c *poolStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquired
+	ch <- c.idle
+	ch <- c.max
+	ch <- c.waitTime
+}
+
+func (c *poolStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.pool == nil {
+		return
+	}
+	stat := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquired, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.max, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.waitTime, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
+
+// RegisterPoolCollector registers a pgxpool stats collector for pool with
+// the default Prometheus registry. Call once at startup, after the pool
+// passed in has been connected (e.g. db.Connect), with db.Pool as the
+// argument.
+func RegisterPoolCollector(pool *pgxpool.Pool) {
+	prometheus.MustRegister(newPoolStatsCollector(pool))
+}
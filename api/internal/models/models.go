@@ -98,12 +98,53 @@ type State struct {
 	SaturdayReplacementRule SaturdayRule   `json:"saturday_replacement_rule" db:"saturday_replacement_rule"`
 }
 
+// Country is a row in the `countries` table, keyed by ISO 3166-1 alpha-2
+// code. It exists so `holidays`/`states` can carry a `country_code` column
+// and the API can eventually serve more than Malaysia - see
+// internal/providers.CountryProvider for how a country plugs in.
+type Country struct {
+	Code string `json:"code" db:"code"` // ISO 3166-1 alpha-2, e.g. "MY"
+	Name string `json:"name" db:"name"`
+}
+
+// ObservanceShift says how a rule-derived occurrence that lands on a
+// weekend should be moved, mirroring the "observed on the following Monday"
+// gazetting convention used for several recurring holidays.
+type ObservanceShift string
+
+const (
+	ObservanceNextWeekday    ObservanceShift = "NEXT_WEEKDAY"
+	ObservanceNearestWeekday ObservanceShift = "NEAREST_WEEKDAY"
+	ObservanceNone           ObservanceShift = "NONE"
+)
+
+// HolidayRule describes a recurring holiday as an RFC 5545 RRULE rather than
+// a pre-seeded row per year. The rule-expansion service (internal/rules)
+// materializes it into Holiday occurrences on demand for years that have no
+// gazetted row yet - see db.GetHolidays. It deliberately mirrors Holiday's
+// descriptive fields (Name, Type, Religion, ...) so an expanded occurrence
+// looks the same to API consumers as a gazetted one.
+type HolidayRule struct {
+	ID               string          `json:"id" db:"id"`
+	Name             string          `json:"name" db:"name"`
+	NameEn           *string         `json:"name_en" db:"name_en"`
+	RRule            string          `json:"rrule" db:"rrule"` // e.g. "FREQ=YEARLY;BYMONTH=8;BYMONTHDAY=31"
+	ObservanceShift  ObservanceShift `json:"observance_shift" db:"observance_shift"`
+	Type             HolidayType     `json:"type" db:"type"`
+	States           []string        `json:"states" db:"states"`
+	Description      *string         `json:"description" db:"description"`
+	Religion         *ReligionType   `json:"religion" db:"religion"`
+	GazetteReference *string         `json:"gazette_reference" db:"gazette_reference"`
+}
+
 type Meta struct {
 	TotalCount   int       `json:"total_count"`
 	Year         *int      `json:"year,omitempty"`
 	LastUpdated  time.Time `json:"last_updated"`
 	GeneratedAt  time.Time `json:"generated_at"`
 	DataVersion  string    `json:"data_version"`
+	NextCursor   string    `json:"next_cursor,omitempty"`
+	PrevCursor   string    `json:"prev_cursor,omitempty"`
 }
 
 type APIResponse struct {
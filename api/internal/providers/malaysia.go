@@ -0,0 +1,42 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+)
+
+func init() {
+	Register(&malaysiaProvider{})
+}
+
+// malaysiaProvider wraps the existing Postgres-backed Malaysia dataset so it
+// plugs into the same CountryProvider interface new countries will use,
+// rather than being special-cased by the handler layer.
+type malaysiaProvider struct{}
+
+func (p *malaysiaProvider) Code() string {
+	return "MY"
+}
+
+func (p *malaysiaProvider) LoadSeedData(ctx context.Context) ([]models.Holiday, []models.State, error) {
+	holidays, err := db.GetHolidays(ctx, 0, "", 0, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	states, err := db.GetStates(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return holidays, states, nil
+}
+
+// ComputeDerived expands the registered HolidayRule set (RRULE-based
+// recurring observances, e.g. "first Monday of June") into occurrences for
+// year, via the same engine db.GetHolidays falls back to for un-seeded
+// years. It returns no error for a year with no matching rules - an empty
+// result just means nothing was derivable, not a failure.
+func (p *malaysiaProvider) ComputeDerived(ctx context.Context, year int) ([]models.Holiday, error) {
+	return db.ExpandRulesForYear(ctx, year, 0, "")
+}
@@ -0,0 +1,51 @@
+// Package providers defines the CountryProvider extension point that lets
+// the API serve more than Malaysia. Each country's seed data and
+// calendar-specific derivation (lunar, Islamic, etc.) lives behind this
+// interface in its own file/package rather than being special-cased in
+// internal/db.
+package providers
+
+import (
+	"context"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+)
+
+// CountryProvider is implemented once per supported country.
+type CountryProvider interface {
+	// Code returns the ISO 3166-1 alpha-2 code this provider serves, e.g. "MY".
+	Code() string
+
+	// LoadSeedData returns the holidays/states a fresh import should write
+	// for this country.
+	LoadSeedData(ctx context.Context) ([]models.Holiday, []models.State, error)
+
+	// ComputeDerived returns any holidays for `year` that aren't pre-seeded
+	// rows but are instead computed on demand (lunar/Islamic-calendar
+	// observances, "nth weekday of month" rules, etc).
+	ComputeDerived(ctx context.Context, year int) ([]models.Holiday, error)
+}
+
+// registry holds the providers registered via Register, keyed by country code.
+var registry = map[string]CountryProvider{}
+
+// Register adds a provider to the registry. Call from an init() in the
+// provider's own file.
+func Register(p CountryProvider) {
+	registry[p.Code()] = p
+}
+
+// Get looks up a registered provider by ISO country code.
+func Get(code string) (CountryProvider, bool) {
+	p, ok := registry[code]
+	return p, ok
+}
+
+// Codes returns the ISO codes of every registered provider.
+func Codes() []string {
+	codes := make([]string, 0, len(registry))
+	for code := range registry {
+		codes = append(codes, code)
+	}
+	return codes
+}
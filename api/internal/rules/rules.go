@@ -0,0 +1,131 @@
+// Package rules materializes a models.HolidayRule's RRULE into concrete
+// Holiday occurrences for a given year, so future years that have no
+// pre-seeded row still return something sensible from GET /holidays.
+package rules
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/teambition/rrule-go"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+)
+
+// anchorLocation is the timezone every rule is expanded in. All the
+// holidays this API serves are Malaysian, so occurrences are computed
+// against local midnight in Kuala Lumpur rather than UTC - otherwise a
+// BYMONTHDAY=31 rule could roll over to the 1st in some timezones.
+var anchorLocation = func() *time.Location {
+	loc, err := time.LoadLocation("Asia/Kuala_Lumpur")
+	if err != nil {
+		// Asia/Kuala_Lumpur has no DST and a fixed +08:00 offset, so this
+		// is a safe fallback if the tzdata package isn't installed.
+		return time.FixedZone("Asia/Kuala_Lumpur", 8*60*60)
+	}
+	return loc
+}()
+
+// dtstartAnchor is an arbitrary early date used as the RRULE's DTSTART.
+// BYMONTH/BYMONTHDAY/BYDAY rules don't care what year DTSTART falls in -
+// only Between's bounds determine which occurrences come out - so a fixed
+// anchor lets the same *rrule.RRule be reused for any requested year.
+var dtstartAnchor = time.Date(1970, 1, 1, 0, 0, 0, 0, anchorLocation)
+
+// ValidateRRule reports whether s parses as an RFC 5545 RRULE, without
+// expanding it. Callers that persist a HolidayRule (e.g.
+// handlers.CreateHolidayRule) should call this before writing, since
+// db.ExpandRulesForYear/Expand run unattended later and a malformed rule
+// would otherwise only surface there, logged and skipped, with its
+// occurrences missing from every future GET /holidays for the affected year.
+func ValidateRRule(s string) error {
+	opts, err := rrule.StrToROption(s)
+	if err != nil {
+		return fmt.Errorf("invalid RRULE %q: %w", s, err)
+	}
+	opts.Dtstart = dtstartAnchor
+	if _, err := rrule.NewRRule(*opts); err != nil {
+		return fmt.Errorf("invalid RRULE %q: %w", s, err)
+	}
+	return nil
+}
+
+// Expand materializes rule into Holiday rows whose Date falls within the
+// given calendar year, applying the rule's ObservanceShift to occurrences
+// that land on a weekend. Each occurrence gets a deterministic ID derived
+// from the rule ID and year, so re-expanding the same rule/year is
+// idempotent.
+func Expand(rule models.HolidayRule, year int) ([]models.Holiday, error) {
+	opts, err := rrule.StrToROption(rule.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RRULE %q: %w", rule.RRule, err)
+	}
+	opts.Dtstart = dtstartAnchor
+
+	r, err := rrule.NewRRule(*opts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RRULE %q: %w", rule.RRule, err)
+	}
+
+	// Scan a window padded a few days past each boundary, not just
+	// [yearStart, yearEnd): a raw occurrence just outside year can still
+	// shift into it (e.g. Jan 1 falling on a Saturday, observed the
+	// nearest weekday, lands in December of the prior year's scan
+	// otherwise). Filtering the final, shifted date down to exactly `year`
+	// below is what actually decides membership, so the window only needs
+	// to be wide enough to not miss a shiftable occurrence; a week is far
+	// more than any ObservanceShift moves a date.
+	scanStart := time.Date(year, 1, 1, 0, 0, 0, 0, anchorLocation).AddDate(0, 0, -7)
+	scanEnd := time.Date(year+1, 1, 1, 0, 0, 0, 0, anchorLocation).AddDate(0, 0, 7)
+
+	occurrences := r.Between(scanStart, scanEnd, true)
+
+	holidays := make([]models.Holiday, 0, len(occurrences))
+	for _, occ := range occurrences {
+		date := shiftForObservance(occ, rule.ObservanceShift)
+		if date.Year() != year {
+			continue
+		}
+
+		holidays = append(holidays, models.Holiday{
+			// ID is keyed by the raw (unshifted) occurrence's year, not the
+			// shifted date's year - an occurrence whose observance shift
+			// carries it into the neighboring year would otherwise collide
+			// with that year's own native occurrence, since both would
+			// round to the same date.Year().
+			ID:               fmt.Sprintf("rule:%s:%d", rule.ID, occ.Year()),
+			Name:             rule.Name,
+			NameEn:           rule.NameEn,
+			Date:             models.Date{Time: date},
+			DayOfWeek:        date.Weekday().String(),
+			Type:             rule.Type,
+			States:           rule.States,
+			Description:      rule.Description,
+			Religion:         rule.Religion,
+			GazetteReference: rule.GazetteReference,
+		})
+	}
+
+	return holidays, nil
+}
+
+// shiftForObservance applies the rule's weekend-observance policy to a raw
+// occurrence date.
+func shiftForObservance(date time.Time, shift models.ObservanceShift) time.Time {
+	switch shift {
+	case models.ObservanceNextWeekday:
+		for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			date = date.AddDate(0, 0, 1)
+		}
+	case models.ObservanceNearestWeekday:
+		switch date.Weekday() {
+		case time.Saturday:
+			date = date.AddDate(0, 0, -1)
+		case time.Sunday:
+			date = date.AddDate(0, 0, 1)
+		}
+	case models.ObservanceNone, "":
+		// no shift
+	}
+	return date
+}
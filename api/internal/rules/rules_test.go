@@ -0,0 +1,53 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/models"
+)
+
+func TestExpand_NewYearsDayDoesNotLeakIntoNeighboringYear(t *testing.T) {
+	rule := models.HolidayRule{ID: "newyear", Name: "New Year's Day", RRule: "FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=1"}
+
+	y2027, err := Expand(rule, 2027)
+	assert.NoError(t, err)
+	y2028, err := Expand(rule, 2028)
+	assert.NoError(t, err)
+
+	assert.Len(t, y2027, 1)
+	assert.Equal(t, "2027-01-01", y2027[0].Date.Time.Format("2006-01-02"))
+
+	assert.Len(t, y2028, 1)
+	assert.Equal(t, "2028-01-01", y2028[0].Date.Time.Format("2006-01-02"))
+}
+
+func TestExpand_ObservanceShiftAcrossYearBoundaryFilesUnderTheShiftedYear(t *testing.T) {
+	// 2028-01-01 is a Saturday; NEAREST_WEEKDAY shifts it backward to
+	// 2027-12-31, so the occurrence "belongs" to 2027's expansion once
+	// shifted, alongside 2027's own native (unshifted) Jan 1 occurrence -
+	// and the two must not collide on the same Holiday ID.
+	rule := models.HolidayRule{
+		ID: "newyear", Name: "New Year's Day", RRule: "FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=1",
+		ObservanceShift: models.ObservanceNearestWeekday,
+	}
+
+	y2027, err := Expand(rule, 2027)
+	assert.NoError(t, err)
+	y2028, err := Expand(rule, 2028)
+	assert.NoError(t, err)
+
+	if assert.Len(t, y2027, 2) {
+		assert.Equal(t, "2027-01-01", y2027[0].Date.Time.Format("2006-01-02"))
+		assert.Equal(t, "2027-12-31", y2027[1].Date.Time.Format("2006-01-02"))
+		assert.NotEqual(t, y2027[0].ID, y2027[1].ID)
+	}
+
+	assert.Len(t, y2028, 0)
+}
+
+func TestValidateRRule(t *testing.T) {
+	assert.NoError(t, ValidateRRule("FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=1"))
+	assert.Error(t, ValidateRRule("garbage"))
+}
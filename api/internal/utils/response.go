@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 
@@ -33,6 +35,45 @@ func RespondWithMeta(w http.ResponseWriter, status int, data interface{}, meta m
 }
 
 
+// computeETag derives a weak ETag from a data version fingerprint and the
+// request's query string, so two requests for the same dataVersion but
+// different filters don't collide on the same ETag.
+func computeETag(dataVersion string, r *http.Request) string {
+	h := sha256.Sum256([]byte(dataVersion + "?" + r.URL.RawQuery))
+	return `W/"` + hex.EncodeToString(h[:])[:16] + `"`
+}
+
+// RespondJSONCached behaves like RespondJSON but additionally sets ETag and
+// Cache-Control headers derived from dataVersion, and short-circuits with
+// 304 Not Modified when the client's If-None-Match matches.
+func RespondJSONCached(w http.ResponseWriter, r *http.Request, status int, data interface{}, dataVersion string) {
+	etag := computeETag(dataVersion, r)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	RespondJSON(w, status, data)
+}
+
+// RespondWithMetaCached is the ETag/If-None-Match aware counterpart of
+// RespondWithMeta.
+func RespondWithMetaCached(w http.ResponseWriter, r *http.Request, status int, data interface{}, meta models.Meta, dataVersion string) {
+	etag := computeETag(dataVersion, r)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	RespondWithMeta(w, status, data, meta)
+}
+
 // RespondError sends a JSON error response
 func RespondError(w http.ResponseWriter, status int, code, message string) {
 	response := models.ErrorResponse{
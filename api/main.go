@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/cache"
 	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/db"
 	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/handlers"
+	"github.com/junsiong2008/malaysia-public-holiday-api/api/internal/metrics"
+	_ "github.com/junsiong2008/malaysia-public-holiday-api/api/internal/providers"
 )
 
 func main() {
@@ -23,6 +29,24 @@ func main() {
 	}
 	defer db.Close()
 
+	metrics.RegisterPoolCollector(db.Pool)
+
+	// Load the holidays/states cache before serving traffic, then keep it
+	// warm on a refresh interval, on SIGHUP, and via POST /admin/reload.
+	ctx := context.Background()
+	if err := cache.Load(ctx); err != nil {
+		log.Printf("Failed to load initial cache, falling back to direct DB reads: %v", err)
+	}
+
+	refreshInterval := 15 * time.Minute
+	if v := os.Getenv("CACHE_REFRESH_INTERVAL_SECONDS"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+			refreshInterval = time.Duration(secs) * time.Second
+		}
+	}
+	cache.StartBackgroundRefresh(ctx, refreshInterval)
+	cache.WatchSIGHUP(ctx)
+
 	// Setup Router
     r := handlers.NewRouter()
 